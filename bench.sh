@@ -2,9 +2,9 @@
 
 set -eu
 
-gopath_is_defined=$GOPATH
+cd "$(dirname "$0")"
 
-go test -bench=. radixsort \
+go test -bench=. . \
   | grep "^Benchmark" \
   | tr '_' ' ' \
   | awk '{print $2, $4, $3, $6}' \
@@ -0,0 +1,22 @@
+#!/bin/bash
+
+# Vets and compiles the tests for 32bits architectures, where int and uint are
+# sorted as int32 and uint32. Tests are run for the architectures the host can
+# execute natively, like 386 on amd64. Every architecture is checked with and
+# without the purego build tag.
+
+set -eu
+
+cd "$(dirname "$0")"
+
+for tags in "" purego; do
+  for arch in 386 arm mipsle; do
+    echo "GOARCH=$arch tags=$tags"
+    GOARCH=$arch go vet -tags "$tags" .
+    GOARCH=$arch go test -tags "$tags" -c -o /dev/null .
+  done
+
+  if [ "$(go env GOHOSTARCH)" = amd64 ]; then
+    GOARCH=386 go test -tags "$tags" .
+  fi
+done
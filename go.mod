@@ -0,0 +1,3 @@
+module radixsort
+
+go 1.21
@@ -0,0 +1,166 @@
+package radixsort
+
+import (
+	"math"
+)
+
+// Argsort functions return the permutation that sorts xs, without modifying xs:
+// xs[is[0]] <= xs[is[1]] <= ... The permutation is stable, indices of equal
+// elements are in increasing order. ArgsortInto variants write the permutation
+// into the first len(xs) elements of is, and panic if is is too short.
+
+// Argsort for int32.
+func Int32Argsort(xs []int32) []int32 {
+	is := make([]int32, len(xs))
+	Int32ArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for int32 into a caller-provided index slice.
+func Int32ArgsortInto(xs []int32, is []int32) {
+	ks, is := make([]uint32, len(xs)), argsort_indices(len(xs), is)
+	for i, x := range xs {
+		ks[i] = uint32(x) ^ 1<<31
+	}
+	uint32_pairs(ks, is)
+}
+
+// Argsort for uint32.
+func Uint32Argsort(xs []uint32) []int32 {
+	is := make([]int32, len(xs))
+	Uint32ArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for uint32 into a caller-provided index slice.
+func Uint32ArgsortInto(xs []uint32, is []int32) {
+	ks, is := make([]uint32, len(xs)), argsort_indices(len(xs), is)
+	copy(ks, xs)
+	uint32_pairs(ks, is)
+}
+
+// Argsort for int64.
+func Int64Argsort(xs []int64) []int32 {
+	is := make([]int32, len(xs))
+	Int64ArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for int64 into a caller-provided index slice.
+func Int64ArgsortInto(xs []int64, is []int32) {
+	ks, is := make([]uint64, len(xs)), argsort_indices(len(xs), is)
+	for i, x := range xs {
+		ks[i] = uint64(x) ^ 1<<63
+	}
+	uint64_pairs(ks, is)
+}
+
+// Argsort for uint64.
+func Uint64Argsort(xs []uint64) []int32 {
+	is := make([]int32, len(xs))
+	Uint64ArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for uint64 into a caller-provided index slice.
+func Uint64ArgsortInto(xs []uint64, is []int32) {
+	ks, is := make([]uint64, len(xs)), argsort_indices(len(xs), is)
+	copy(ks, xs)
+	uint64_pairs(ks, is)
+}
+
+// Argsort for int.
+func IntArgsort(xs []int) []int32 {
+	is := make([]int32, len(xs))
+	IntArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for int into a caller-provided index slice.
+func IntArgsortInto(xs []int, is []int32) {
+	ks, is := make([]uint64, len(xs)), argsort_indices(len(xs), is)
+	for i, x := range xs {
+		ks[i] = uint64(x) ^ 1<<63
+	}
+	uint64_pairs(ks, is)
+}
+
+// Argsort for uint.
+func UintArgsort(xs []uint) []int32 {
+	is := make([]int32, len(xs))
+	UintArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for uint into a caller-provided index slice.
+func UintArgsortInto(xs []uint, is []int32) {
+	ks, is := make([]uint64, len(xs)), argsort_indices(len(xs), is)
+	for i, x := range xs {
+		ks[i] = uint64(x)
+	}
+	uint64_pairs(ks, is)
+}
+
+// Argsort for float32, in the same order as Float32: NaNs first, -0 and +0
+// equal.
+func Float32Argsort(xs []float32) []int32 {
+	is := make([]int32, len(xs))
+	Float32ArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for float32 into a caller-provided index slice.
+func Float32ArgsortInto(xs []float32, is []int32) {
+	ks, is := make([]uint32, len(xs)), argsort_indices(len(xs), is)
+	for i, x := range xs {
+		switch {
+		case x != x:
+			ks[i] = 0 // below the key of -Inf
+		case x == 0:
+			ks[i] = float32_key(0)
+		default:
+			ks[i] = float32_key(math.Float32bits(x))
+		}
+	}
+	uint32_pairs(ks, is)
+}
+
+// Argsort for float64, in the same order as Float64: NaNs first, -0 and +0
+// equal.
+func Float64Argsort(xs []float64) []int32 {
+	is := make([]int32, len(xs))
+	Float64ArgsortInto(xs, is)
+	return is
+}
+
+// Argsort for float64 into a caller-provided index slice.
+func Float64ArgsortInto(xs []float64, is []int32) {
+	ks, is := make([]uint64, len(xs)), argsort_indices(len(xs), is)
+	for i, x := range xs {
+		switch {
+		case x != x:
+			ks[i] = 0 // below the key of -Inf
+		case x == 0:
+			ks[i] = float64_key(0)
+		default:
+			ks[i] = float64_key(math.Float64bits(x))
+		}
+	}
+	uint64_pairs(ks, is)
+}
+
+// argsort_indices checks that is can hold n indices and initializes it to the
+// identity permutation.
+func argsort_indices(n int, is []int32) []int32 {
+	if n > math.MaxInt32 {
+		panic("radixsort: argsort of more than math.MaxInt32 elements")
+	}
+	if len(is) < n {
+		panic("radixsort: index slice is shorter than the input slice")
+	}
+	is = is[:n]
+	for i := range is {
+		is[i] = int32(i)
+	}
+	return is
+}
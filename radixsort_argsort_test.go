@@ -0,0 +1,98 @@
+package radixsort
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArgsort(t *testing.T) {
+	sizes := []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+	for _, size := range sizes {
+		var (
+			i32 = int32_pop(size)
+			u32 = uint32_pop(size)
+			i64 = int64_pop(size)
+			u64 = uint64_pop(size)
+			i   = int_pop(size)
+			u   = uint_pop(size)
+			f32 = float32_pop(size)
+			f64 = float64_pop(size)
+		)
+		for n := 0; n < size; n += 3 { // add duplicates to check stability
+			i32[n] &= 0xF
+			u32[n] &= 0xF
+			i64[n] &= 0xF
+			u64[n] &= 0xF
+			i[n] &= 0xF
+			u[n] &= 0xF
+			f32[n] = float32(n % 5)
+			f64[n] = float64(n % 5)
+		}
+		argsort_check(t, "int32", i32, Int32Argsort(i32), int32_less)
+		argsort_check(t, "uint32", u32, Uint32Argsort(u32), uint32_less)
+		argsort_check(t, "int64", i64, Int64Argsort(i64), int64_less)
+		argsort_check(t, "uint64", u64, Uint64Argsort(u64), uint64_less)
+		argsort_check(t, "int", i, IntArgsort(i), int_less)
+		argsort_check(t, "uint", u, UintArgsort(u), uint_less)
+		argsort_check(t, "float32", f32, Float32Argsort(f32), float32_less)
+		argsort_check(t, "float64", f64, Float64Argsort(f64), float64_less)
+	}
+}
+
+func TestArgsortInto(t *testing.T) {
+	xs := int64_pop(1000)
+	is := make([]int32, 2000)
+	Int64ArgsortInto(xs, is)
+	argsort_check(t, "int64 into", xs, is[:len(xs)], int64_less)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ArgsortInto did not panic on a short index slice")
+		}
+	}()
+	Int64ArgsortInto(xs, is[:10])
+}
+
+func Benchmark_Int64_Argsort_100000(b *testing.B) {
+	xs := int64_pop(100000)
+	is := make([]int32, len(xs))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Int64ArgsortInto(xs, is)
+	}
+}
+
+// argsort_check verifies that is is a permutation sorting xs, and that equal
+// elements are ordered by index.
+func argsort_check[T any](t *testing.T, name string, xs []T, is []int32, less func(a, b T) bool) {
+	if len(is) != len(xs) {
+		t.Errorf("%s argsort returned %d indices for %d elements", name, len(is), len(xs))
+		return
+	}
+	seen := make([]bool, len(xs))
+	for n, i := range is {
+		if seen[i] {
+			t.Errorf("%s argsort of size %d is not a permutation", name, len(xs))
+			return
+		}
+		seen[i] = true
+		if n == 0 {
+			continue
+		}
+		a, b := xs[is[n-1]], xs[i]
+		if less(b, a) || (!less(a, b) && is[n-1] > i) {
+			t.Errorf("%s argsort of size %d is not stably sorted at index %d", name, len(xs), n)
+			return
+		}
+	}
+}
+
+func int32_less(a, b int32) bool   { return a < b }
+func uint32_less(a, b uint32) bool { return a < b }
+func int64_less(a, b int64) bool   { return a < b }
+func uint64_less(a, b uint64) bool { return a < b }
+func int_less(a, b int) bool       { return a < b }
+func uint_less(a, b uint) bool     { return a < b }
+
+func float32_less(a, b float32) bool { return a < b || (a != a && b == b) }
+func float64_less(a, b float64) bool { return a < b || (math.IsNaN(a) && !math.IsNaN(b)) }
@@ -0,0 +1,53 @@
+package radixsort
+
+// SortBy sorts xs in increasing order of the uint64 key extracted from every
+// element by key. The key function is called once per element. The sort is
+// stable: elements with equal keys keep their input order.
+// To sort by a signed key k, return uint64(k) ^ 1<<63.
+func SortBy[T any](xs []T, key func(T) uint64) {
+	ks := make([]uint64, len(xs))
+	for i, x := range xs {
+		ks[i] = key(x)
+	}
+	uint64_pairs(ks, xs)
+}
+
+// SortBy32 is like SortBy for uint32 keys, and uses half as many radix passes.
+// To sort by a signed key k, return uint32(k) ^ 1<<31.
+func SortBy32[T any](xs []T, key func(T) uint32) {
+	ks := make([]uint32, len(xs))
+	for i, x := range xs {
+		ks[i] = key(x)
+	}
+	uint32_pairs(ks, xs)
+}
+
+// SortFunc sorts xs in increasing order of the integer key extracted from
+// every element by key, like slices.SortStableFunc with a comparator of keys.
+// Keys of 32bits or less use half as many radix passes as 64bits keys. The key
+// function is called once per element. The sort is stable: elements with equal
+// keys keep their input order.
+func SortFunc[S ~[]E, E any, K Integer](xs S, key func(E) K) {
+	signed := integer_signed[K]()
+	if integer_size[K]() <= 4 {
+		var flip uint32
+		if signed {
+			flip = 1 << 31 // keys narrower than 32bits are sign extended
+		}
+		ks := make([]uint32, len(xs))
+		for i, x := range xs {
+			ks[i] = uint32(key(x)) ^ flip
+		}
+		uint32_pairs(ks, []E(xs))
+		return
+	}
+	var flip uint64
+	if signed {
+		flip = 1 << 63
+	}
+	ks := make([]uint64, len(xs))
+	for i, x := range xs {
+		ks[i] = uint64(key(x)) ^ flip
+	}
+	uint64_pairs(ks, []E(xs))
+}
@@ -0,0 +1,139 @@
+package radixsort
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"testing"
+)
+
+type record struct {
+	key uint64
+	idx int
+}
+
+func TestSortBy(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+		masks  = []uint64{^uint64(0), 0xFF, 0xFF00000000000F00}
+		sorter = map[string]func([]record){
+			"SortBy":   func(xs []record) { SortBy(xs, record_key) },
+			"SortBy32": func(xs []record) { SortBy32(xs, record_key32) },
+		}
+	)
+	for _, size := range sizes {
+		for _, mask := range masks {
+			xs := record_pop(size, mask)
+			for desc, s := range sorter {
+				ys := make([]record, size)
+				copy(ys, xs)
+				s(ys)
+				less := func(i, j int) bool { return ys[i].key < ys[j].key }
+				if desc == "SortBy32" {
+					less = func(i, j int) bool { return record_key32(ys[i]) < record_key32(ys[j]) }
+				}
+				if !sort.SliceIsSorted(ys, less) {
+					t.Errorf("array of size %d with key mask %x was not correctly sorted by %s", size, mask, desc)
+				}
+				for i := 1; i < len(ys); i++ {
+					if !less(i-1, i) && ys[i-1].idx > ys[i].idx {
+						t.Errorf("array of size %d with key mask %x was not stably sorted by %s", size, mask, desc)
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func Benchmark_Record_SortBy_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) { SortBy(xs, record_key) }, 100000)
+}
+func Benchmark_Record_SortBy32_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) { SortBy32(xs, record_key32) }, 100000)
+}
+func Benchmark_Record_StandardSort_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) {
+		sort.SliceStable(xs, func(i, j int) bool { return xs[i].key < xs[j].key })
+	}, 100000)
+}
+
+func benchmarkRecord(b *testing.B, sorter func([]record), size int) {
+	ys := make([][]record, b.N)
+	for n := range ys {
+		ys[n] = record_pop(size, ^uint64(0))
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+func record_key(r record) uint64   { return r.key }
+func record_key32(r record) uint32 { return uint32(r.key) }
+
+// record_pop returns records with random keys restricted to mask, so that small
+// masks give duplicate-heavy inputs, tagged by their input index.
+func record_pop(size int, mask uint64) []record {
+	xs := make([]record, size)
+	for i := range xs {
+		xs[i] = record{key: g.next() & mask, idx: i}
+	}
+	return xs
+}
+
+func TestSortFunc(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+		masks  = []uint64{^uint64(0), 0xFF, 0xFF00000000000F00}
+		sorter = map[string]func([]record){
+			"int64":  func(xs []record) { SortFunc(xs, func(r record) int64 { return int64(r.key) }) },
+			"uint64": func(xs []record) { SortFunc(xs, func(r record) uint64 { return r.key }) },
+			"int32":  func(xs []record) { SortFunc(xs, func(r record) int32 { return int32(r.key) }) },
+			"uint32": func(xs []record) { SortFunc(xs, func(r record) uint32 { return uint32(r.key) }) },
+			"int8":   func(xs []record) { SortFunc(xs, func(r record) int8 { return int8(r.key) }) },
+			"uint16": func(xs []record) { SortFunc(xs, func(r record) uint16 { return uint16(r.key) }) },
+		}
+		// keys maps the key of every sorter to a uint64 of the same order
+		keys = map[string]func(record) uint64{
+			"int64":  func(r record) uint64 { return r.key ^ 1<<63 },
+			"uint64": func(r record) uint64 { return r.key },
+			"int32":  func(r record) uint64 { return uint64(int32(r.key)) ^ 1<<63 },
+			"uint32": func(r record) uint64 { return uint64(uint32(r.key)) },
+			"int8":   func(r record) uint64 { return uint64(int8(r.key)) ^ 1<<63 },
+			"uint16": func(r record) uint64 { return uint64(uint16(r.key)) },
+		}
+	)
+	for _, size := range sizes {
+		for _, mask := range masks {
+			xs := record_pop(size, mask)
+			for desc, s := range sorter {
+				ys := make([]record, size)
+				copy(ys, xs)
+				s(ys)
+				key := keys[desc]
+				less := func(i, j int) bool { return key(ys[i]) < key(ys[j]) }
+				if !sort.SliceIsSorted(ys, less) {
+					t.Errorf("array of size %d with key mask %x was not correctly sorted by %s SortFunc", size, mask, desc)
+				}
+				for i := 1; i < len(ys); i++ {
+					if !less(i-1, i) && ys[i-1].idx > ys[i].idx {
+						t.Errorf("array of size %d with key mask %x was not stably sorted by %s SortFunc", size, mask, desc)
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func Benchmark_Record_SortFunc_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) {
+		SortFunc(xs, func(r record) int64 { return int64(r.key) })
+	}, 100000)
+}
+func Benchmark_Record_StandardSortFunc_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) {
+		slices.SortStableFunc(xs, func(a, b record) int { return cmp.Compare(int64(a.key), int64(b.key)) })
+	}, 100000)
+}
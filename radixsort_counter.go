@@ -0,0 +1,21 @@
+package radixsort
+
+import (
+	"math"
+)
+
+// counter is the type of radix count and offset tables. uint32 counters keep
+// tables small, but cannot index arrays of more than math.MaxUint32 elements,
+// which are sorted with uint64 counters instead.
+type counter interface {
+	uint32 | uint64
+}
+
+// Arrays longer than wide_threshold are sorted with uint64 counters. This is a
+// variable so that tests can exercise uint64 counters on small arrays.
+var wide_threshold uint64 = math.MaxUint32
+
+// counter_wide returns true if an array of n elements needs uint64 counters.
+func counter_wide(n int) bool {
+	return uint64(n) > wide_threshold
+}
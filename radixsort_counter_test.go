@@ -0,0 +1,91 @@
+package radixsort
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// TestWideCounters lowers wide_threshold to sort small arrays with uint64
+// counters. Inputs are drawn from an alphabet of 4 random values, so that every
+// radix bucket holds a quarter of the array and stays above the threshold down
+// to the last digit, as with arrays of more than math.MaxUint32 elements.
+func TestWideCounters(t *testing.T) {
+	defer func(threshold uint64) { wide_threshold = threshold }(wide_threshold)
+	wide_threshold = 1000
+
+	if counter_wide(1000) || !counter_wide(1001) {
+		t.Errorf("counter_wide does not switch to uint64 counters above wide_threshold")
+	}
+
+	sizes := []int{999, 1000, 1001, 1e5, parallel_threshold + 1}
+	for _, size := range sizes {
+		var (
+			i32 = int32_alphabet(size)
+			i64 = int64_alphabet(size)
+		)
+		int32s := map[string]func([]int32){
+			"int32 radix sort MSD":      Int32MSD,
+			"int32 radix sort LSD":      Int32LSD,
+			"int32 radix sort in place": Int32InPlace,
+			"int32 parallel MSD":        func(xs []int32) { Int32MSDParallel(xs, 4) },
+			"int32 parallel LSD":        func(xs []int32) { Int32LSDParallel(xs, 4) },
+			"int32 pairs":               func(xs []int32) { SortPairs(xs, make([]byte, len(xs))) },
+		}
+		for desc, s := range int32s {
+			ys := append([]int32(nil), i32...)
+			s(ys)
+			if !sort.IsSorted(byInt32(ys)) {
+				t.Errorf("array of size %d was not correctly sorted by %s with uint64 counters", size, desc)
+			}
+		}
+		int64s := map[string]func([]int64){
+			"int64 radix sort MSD":      Int64MSD,
+			"int64 radix sort LSD":      Int64LSD,
+			"int64 radix sort in place": Int64InPlace,
+			"int64 parallel MSD":        func(xs []int64) { Int64MSDParallel(xs, 4) },
+			"int64 parallel LSD":        func(xs []int64) { Int64LSDParallel(xs, 4) },
+			"int64 pairs":               func(xs []int64) { SortPairs(xs, make([]byte, len(xs))) },
+			"int64 sorter":              (&Sorter{}).Int64,
+		}
+		for desc, s := range int64s {
+			ys := append([]int64(nil), i64...)
+			s(ys)
+			if !sort.IsSorted(byInt64(ys)) {
+				t.Errorf("array of size %d was not correctly sorted by %s with uint64 counters", size, desc)
+			}
+		}
+	}
+}
+
+func TestCounterThreshold(t *testing.T) {
+	if counter_wide(math.MaxInt32) {
+		t.Errorf("arrays of math.MaxInt32 elements should use uint32 counters")
+	}
+	n := uint64(math.MaxUint32) + 1
+	if n <= math.MaxInt && !counter_wide(int(n)) {
+		t.Errorf("arrays of more than math.MaxUint32 elements should use uint64 counters")
+	}
+}
+
+func int32_alphabet(size int) []int32 {
+	var (
+		as = int32_pop(4)
+		xs = make([]int32, size)
+	)
+	for i := range xs {
+		xs[i] = as[g.next()%4]
+	}
+	return xs
+}
+
+func int64_alphabet(size int) []int64 {
+	var (
+		as = int64_pop(4)
+		xs = make([]int64, size)
+	)
+	for i := range xs {
+		xs[i] = as[g.next()%4]
+	}
+	return xs
+}
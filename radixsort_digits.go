@@ -0,0 +1,407 @@
+package radixsort
+
+// word is the set of element types sorted by the digits kernels. Kernels take
+// the key of x as (uint64(x) ^ flip) - lo, where flip is the sign bit for
+// signed order and lo the smallest key, and sort on its width low bits only.
+type word interface {
+	word32 | word64
+}
+
+// word_histogram counts the radix digits at shift in cs, and sets is to the
+// index of the first element of every bucket. Digits are taken as
+// (offset + (x >> shift)) & 0xFF, where offset is 1<<7 on the most significant
+// digit for signed order. Buckets are laid out in increasing order of digits if
+// mask is 0, and in decreasing order if mask is 0xFF.
+func word_histogram[T word, C counter](xs []T, cs, is *[256]C, offset T, shift uint, mask int) {
+	for _, x := range xs {
+		r := (offset + (x >> shift)) & 0xFF
+		cs[r]++
+	}
+	a := C(0)
+	for i := 0; i < 256; i++ {
+		is[i^mask] = a
+		a += cs[i^mask]
+	}
+}
+
+// digits_lsd sorts xs with least significant digit radix sort on digits of
+// bits bits, 8, 11 or 16, using ys as swap space and the counter width suited to
+// the length of xs. width is the number of significant bits of keys. 16bits
+// digits take their count tables from tables.
+func digits_lsd[T word](xs, ys []T, flip, lo uint64, width, bits uint, tables *digits_tables) {
+	wide := counter_wide(len(xs))
+	switch {
+	case bits == 16 && wide:
+		digits_least_significant_digit16[T, uint64](xs, ys, flip, lo, width, tables)
+	case bits == 16:
+		digits_least_significant_digit16[T, uint32](xs, ys, flip, lo, width, tables)
+	case bits == 11 && wide:
+		digits_least_significant_digit11[T, uint64](xs, ys, flip, lo, width)
+	case bits == 11:
+		digits_least_significant_digit11[T, uint32](xs, ys, flip, lo, width)
+	case wide:
+		digits_least_significant_digit8[T, uint64](xs, ys, flip, lo, width)
+	default:
+		digits_least_significant_digit8[T, uint32](xs, ys, flip, lo, width)
+	}
+}
+
+// digits_msd sorts xs with most significant digit radix sort on digits of up
+// to bits bits, 8, 11 or 16, using temp as swap space and the counter width
+// suited to the length of xs. width is the number of significant bits of keys.
+// 16bits digits take their count tables from tables.
+func digits_msd[T word](xs, temp []T, flip, lo uint64, width, bits uint, cutoff int, tables *digits_tables) {
+	if counter_wide(len(xs)) {
+		digits_most_significant_bucket(xs, temp, flip, lo, width, bits, uint64(cutoff), tables)
+		return
+	}
+	digits_most_significant_bucket(xs, temp, flip, lo, width, bits, uint32(cutoff), tables)
+}
+
+// digit_bits_lsd returns the digit width of least significant digit radix sort
+// for n keys of width bits. 11bits digits save 2 of the 8 passes over 64bits
+// keys, but their 2048 buckets cost more cache misses than the saved passes on
+// large arrays, and more than a single saved pass on any array.
+func digit_bits_lsd(n int, width uint) uint {
+	if (width+7)/8-(width+10)/11 >= 2 && n >= 1<<10 && n < 1<<16 {
+		return 11
+	}
+	return 8
+}
+
+// digit_bits_msd returns the digit width of most significant digit radix sort
+// for n keys: 11bits digits when digits_fill, and 8bits digits otherwise.
+func digit_bits_msd(n int) uint {
+	if digits_fill(n, 11) {
+		return 11
+	}
+	return 8
+}
+
+// digits_fill reports whether n elements suit a digit of bits bits: its buckets
+// hold 2 to 16 elements on average, cheap to sort by insertion sort, or 256
+// elements or more, worth sorting on another digit. Buckets in between are
+// sorted faster after a narrower digit.
+func digits_fill(n int, bits uint) bool {
+	return n >= 2<<bits && n <= 16<<bits || n >= 256<<bits
+}
+
+// digits_tables keeps the count tables of 16bits digits, too large for the
+// stack, so that a Sorter reuses them across sorts. Tables are taken and given
+// back in stack order, as MSD radix sort recurses into buckets. A nil
+// *digits_tables allocates every table. The zero value is ready to use.
+type digits_tables struct {
+	narrow []*[1 << 16]uint32
+	wide   []*[1 << 16]uint64
+	used   int
+}
+
+// digits_table returns a zeroed count table, to give back with digits_release.
+func digits_table[C counter](t *digits_tables) *[1 << 16]C {
+	if t == nil {
+		return new([1 << 16]C)
+	}
+	ts, ok := any(&t.narrow).(*[]*[1 << 16]C)
+	if !ok {
+		ts = any(&t.wide).(*[]*[1 << 16]C)
+	}
+	if t.used == len(*ts) {
+		*ts = append(*ts, new([1 << 16]C))
+	} else {
+		*(*ts)[t.used] = [1 << 16]C{}
+	}
+	t.used++
+	return (*ts)[t.used-1]
+}
+
+// digits_release gives the last n tables taken by digits_table back to t.
+func digits_release(t *digits_tables, n int) {
+	if t != nil {
+		t.used -= n
+	}
+}
+
+// digits_least_significant_digit8 sorts xs on 8bits digits using ys as swap
+// space. ys must have the same length as xs. Digits shared by all elements are
+// not scattered.
+func digits_least_significant_digit8[T word, C counter](xs, ys []T, flip, lo uint64, width uint) {
+	var (
+		passes = int((width + 7) / 8)
+		css    [8][1 << 8]C
+	)
+
+	// count all radix keys
+	for _, x := range xs {
+		k := (uint64(x) ^ flip) - lo
+		for i := 0; i < passes; i++ {
+			css[i][uint8(k)]++
+			k >>= 8
+		}
+	}
+
+	// aggregate radix counts to radix offsets, and find digits shared by all
+	// elements
+	var constant [8]bool
+	for i := 0; i < passes; i++ {
+		cs := &css[i]
+		a := C(0)
+		for j, c := range cs {
+			cs[j] = a
+			a += c
+			constant[i] = constant[i] || c == C(len(xs))
+		}
+	}
+
+	swaps := 0
+	for i := 0; i < passes; i++ {
+		if constant[i] { // scattering would leave xs unchanged
+			continue
+		}
+		var (
+			cs    = &css[i]
+			shift = uint(8 * i)
+		)
+		for _, x := range xs {
+			r := uint8(((uint64(x) ^ flip) - lo) >> shift)
+			ys[cs[r]] = x
+			cs[r]++
+		}
+		xs, ys = ys, xs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the swap space
+		copy(ys, xs)
+	}
+}
+
+// digits_least_significant_digit11 sorts xs on 11bits digits using ys as swap
+// space. ys must have the same length as xs. Digits shared by all elements are
+// not scattered.
+func digits_least_significant_digit11[T word, C counter](xs, ys []T, flip, lo uint64, width uint) {
+	var (
+		passes = int((width + 10) / 11)
+		keys   = ^uint64(0) >> (64 - width) // drop sign extended bits of int32
+		css    [6][1 << 11]C
+	)
+
+	// count all radix keys
+	for _, x := range xs {
+		k := ((uint64(x) ^ flip) - lo) & keys
+		for i := 0; i < passes; i++ {
+			css[i][k&0x7FF]++
+			k >>= 11
+		}
+	}
+
+	// aggregate radix counts to radix offsets, and find digits shared by all
+	// elements
+	var constant [6]bool
+	for i := 0; i < passes; i++ {
+		cs := &css[i]
+		a := C(0)
+		for j, c := range cs {
+			cs[j] = a
+			a += c
+			constant[i] = constant[i] || c == C(len(xs))
+		}
+	}
+
+	swaps := 0
+	for i := 0; i < passes; i++ {
+		if constant[i] { // scattering would leave xs unchanged
+			continue
+		}
+		var (
+			cs    = &css[i]
+			shift = uint(11 * i)
+		)
+		for _, x := range xs {
+			r := ((((uint64(x) ^ flip) - lo) & keys) >> shift) & 0x7FF
+			ys[cs[r]] = x
+			cs[r]++
+		}
+		xs, ys = ys, xs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the swap space
+		copy(ys, xs)
+	}
+}
+
+// digits_least_significant_digit16 sorts xs on 16bits digits using ys as swap
+// space. ys must have the same length as xs. Digits shared by all elements are
+// not scattered.
+func digits_least_significant_digit16[T word, C counter](xs, ys []T, flip, lo uint64, width uint, tables *digits_tables) {
+	var (
+		passes = int((width + 15) / 16)
+		css    [4]*[1 << 16]C
+	)
+	for i := 0; i < passes; i++ {
+		css[i] = digits_table[C](tables)
+	}
+	defer digits_release(tables, passes)
+
+	// count all radix keys
+	for _, x := range xs {
+		k := (uint64(x) ^ flip) - lo
+		for i := 0; i < passes; i++ {
+			css[i][uint16(k)]++
+			k >>= 16
+		}
+	}
+
+	// aggregate radix counts to radix offsets, and find digits shared by all
+	// elements
+	var constant [4]bool
+	for i := 0; i < passes; i++ {
+		cs := css[i]
+		a := C(0)
+		for j, c := range cs {
+			cs[j] = a
+			a += c
+			constant[i] = constant[i] || c == C(len(xs))
+		}
+	}
+
+	swaps := 0
+	for i := 0; i < passes; i++ {
+		if constant[i] { // scattering would leave xs unchanged
+			continue
+		}
+		var (
+			cs    = css[i]
+			shift = uint(16 * i)
+		)
+		for _, x := range xs {
+			r := uint16(((uint64(x) ^ flip) - lo) >> shift)
+			ys[cs[r]] = x
+			cs[r]++
+		}
+		xs, ys = ys, xs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the swap space
+		copy(ys, xs)
+	}
+}
+
+// digits_most_significant_bucket sorts xs, whose keys only differ in their
+// rest low bits, with the widest digit of up to bits bits that digits_fill, or
+// with a 8bits digit. xs is sorted by insertion sort if it has cutoff elements
+// or less.
+func digits_most_significant_bucket[T word, C counter](xs, temp []T, flip, lo uint64, rest, bits uint, cutoff C, tables *digits_tables) {
+	switch n := len(xs); {
+	case n < 2: // already sorted
+	case C(n) <= cutoff:
+		integer_insertion(xs)
+	case bits >= 16 && digits_fill(n, 16):
+		digits_most_significant_digit16(xs, temp, flip, lo, rest, bits, cutoff, tables)
+	case bits >= 11 && digits_fill(n, 11):
+		digits_most_significant_digit11(xs, temp, flip, lo, rest, bits, cutoff, tables)
+	default:
+		digits_most_significant_digit8(xs, temp, flip, lo, rest, bits, cutoff, tables)
+	}
+}
+
+// digits_most_significant_digit8 sorts xs on the 8bits digit just above the
+// rest - 8 low bits, and recurses into the buckets with
+// digits_most_significant_bucket. Keys of xs only differ in their rest low
+// bits, so that the digit may overlap bits of the previous digit when rest is
+// less than 8.
+func digits_most_significant_digit8[T word, C counter](xs, temp []T, flip, lo uint64, rest, bits uint, cutoff C, tables *digits_tables) {
+	var (
+		cs, is [1 << 8]C
+		shift  = rest - min(rest, 8)
+	)
+	for _, x := range xs {
+		cs[uint8(((uint64(x)^flip)-lo)>>shift)]++
+	}
+	a := C(0)
+	for i, c := range cs {
+		is[i] = a
+		a += c
+	}
+	for _, x := range xs {
+		r := uint8(((uint64(x) ^ flip) - lo) >> shift)
+		temp[is[r]] = x
+		is[r]++
+	}
+	copy(xs, temp)
+
+	if shift == 0 { // that was the last radix digit
+		return
+	}
+	var i C
+	for _, c := range cs {
+		j := i + c
+		digits_most_significant_bucket(xs[i:j], temp, flip, lo, shift, bits, cutoff, tables)
+		i = j
+	}
+}
+
+// digits_most_significant_digit11 is digits_most_significant_digit8 for 11bits
+// digits.
+func digits_most_significant_digit11[T word, C counter](xs, temp []T, flip, lo uint64, rest, bits uint, cutoff C, tables *digits_tables) {
+	var (
+		cs, is [1 << 11]C
+		shift  = rest - min(rest, 11)
+	)
+	for _, x := range xs {
+		cs[(((uint64(x)^flip)-lo)>>shift)&0x7FF]++
+	}
+	a := C(0)
+	for i, c := range cs {
+		is[i] = a
+		a += c
+	}
+	for _, x := range xs {
+		r := (((uint64(x) ^ flip) - lo) >> shift) & 0x7FF
+		temp[is[r]] = x
+		is[r]++
+	}
+	copy(xs, temp)
+
+	if shift == 0 { // that was the last radix digit
+		return
+	}
+	var i C
+	for _, c := range cs {
+		j := i + c
+		digits_most_significant_bucket(xs[i:j], temp, flip, lo, shift, bits, cutoff, tables)
+		i = j
+	}
+}
+
+// digits_most_significant_digit16 is digits_most_significant_digit8 for 16bits
+// digits, with a count table from tables that holds the end of every bucket
+// after the scatter.
+func digits_most_significant_digit16[T word, C counter](xs, temp []T, flip, lo uint64, rest, bits uint, cutoff C, tables *digits_tables) {
+	var (
+		is    = digits_table[C](tables)
+		shift = rest - min(rest, 16)
+	)
+	defer digits_release(tables, 1)
+	for _, x := range xs {
+		is[uint16(((uint64(x)^flip)-lo)>>shift)]++
+	}
+	a := C(0)
+	for i, c := range is {
+		is[i] = a
+		a += c
+	}
+	for _, x := range xs {
+		r := uint16(((uint64(x) ^ flip) - lo) >> shift)
+		temp[is[r]] = x
+		is[r]++
+	}
+	copy(xs, temp)
+
+	if shift == 0 { // that was the last radix digit
+		return
+	}
+	var i C
+	for _, j := range is {
+		digits_most_significant_bucket(xs[i:j], temp, flip, lo, shift, bits, cutoff, tables)
+		i = j
+	}
+}
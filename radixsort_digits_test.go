@@ -0,0 +1,83 @@
+package radixsort
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestDigitWidths(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		ranges = map[string]uint64{ // keys only differ in their low bits
+			"all bits": ^uint64(0),
+			"20 bits":  1<<20 - 1,
+			"3 bits":   1<<3 - 1,
+		}
+	)
+	for _, bits := range []int{8, 11, 16} {
+		for _, algo := range []Algorithm{MSD, LSD} {
+			s := &Sorter{Algorithm: algo, DigitBits: bits}
+			for desc, mask := range ranges {
+				for _, size := range sizes {
+					i32, u32 := int32_pop(size), uint32_pop(size)
+					i64, u64 := int64_pop(size), uint64_pop(size)
+					for i := 0; i < size; i++ {
+						i32[i] = int32(uint64(i32[i]) & mask)
+						u32[i] &= uint32(mask)
+						i64[i] = int64(uint64(i64[i]) & mask)
+						u64[i] &= mask
+					}
+					s.Int32(i32)
+					s.Uint32(u32)
+					s.Int64(i64)
+					s.Uint64(u64)
+					if !sort.IsSorted(byInt32(i32)) || !sort.IsSorted(byUint32(u32)) ||
+						!sort.IsSorted(byInt64(i64)) || !sort.IsSorted(byUint64(u64)) {
+						t.Errorf("array of size %d with %s was not correctly sorted by %d bits digits with algorithm %d", size, desc, bits, algo)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDigitWidthPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("sorting with 12 bits digits did not panic")
+		}
+	}()
+	s := &Sorter{DigitBits: 12}
+	s.Int64(int64_pop(1000))
+}
+
+func Benchmark_Int64_RadixLSD8_1000(b *testing.B)     { benchmarkDigits(b, LSD, 8, 1000) }
+func Benchmark_Int64_RadixLSD8_10000(b *testing.B)    { benchmarkDigits(b, LSD, 8, 10000) }
+func Benchmark_Int64_RadixLSD8_100000(b *testing.B)   { benchmarkDigits(b, LSD, 8, 100000) }
+func Benchmark_Int64_RadixLSD8_1000000(b *testing.B)  { benchmarkDigits(b, LSD, 8, 1000000) }
+func Benchmark_Int64_RadixLSD11_1000(b *testing.B)    { benchmarkDigits(b, LSD, 11, 1000) }
+func Benchmark_Int64_RadixLSD11_10000(b *testing.B)   { benchmarkDigits(b, LSD, 11, 10000) }
+func Benchmark_Int64_RadixLSD11_100000(b *testing.B)  { benchmarkDigits(b, LSD, 11, 100000) }
+func Benchmark_Int64_RadixLSD11_1000000(b *testing.B) { benchmarkDigits(b, LSD, 11, 1000000) }
+func Benchmark_Int64_RadixLSD16_1000(b *testing.B)    { benchmarkDigits(b, LSD, 16, 1000) }
+func Benchmark_Int64_RadixLSD16_10000(b *testing.B)   { benchmarkDigits(b, LSD, 16, 10000) }
+func Benchmark_Int64_RadixLSD16_100000(b *testing.B)  { benchmarkDigits(b, LSD, 16, 100000) }
+func Benchmark_Int64_RadixLSD16_1000000(b *testing.B) { benchmarkDigits(b, LSD, 16, 1000000) }
+
+func Benchmark_Int64_RadixMSD8_1000(b *testing.B)     { benchmarkDigits(b, MSD, 8, 1000) }
+func Benchmark_Int64_RadixMSD8_10000(b *testing.B)    { benchmarkDigits(b, MSD, 8, 10000) }
+func Benchmark_Int64_RadixMSD8_100000(b *testing.B)   { benchmarkDigits(b, MSD, 8, 100000) }
+func Benchmark_Int64_RadixMSD8_1000000(b *testing.B)  { benchmarkDigits(b, MSD, 8, 1000000) }
+func Benchmark_Int64_RadixMSD11_1000(b *testing.B)    { benchmarkDigits(b, MSD, 11, 1000) }
+func Benchmark_Int64_RadixMSD11_10000(b *testing.B)   { benchmarkDigits(b, MSD, 11, 10000) }
+func Benchmark_Int64_RadixMSD11_100000(b *testing.B)  { benchmarkDigits(b, MSD, 11, 100000) }
+func Benchmark_Int64_RadixMSD11_1000000(b *testing.B) { benchmarkDigits(b, MSD, 11, 1000000) }
+func Benchmark_Int64_RadixMSD16_1000(b *testing.B)    { benchmarkDigits(b, MSD, 16, 1000) }
+func Benchmark_Int64_RadixMSD16_10000(b *testing.B)   { benchmarkDigits(b, MSD, 16, 10000) }
+func Benchmark_Int64_RadixMSD16_100000(b *testing.B)  { benchmarkDigits(b, MSD, 16, 100000) }
+func Benchmark_Int64_RadixMSD16_1000000(b *testing.B) { benchmarkDigits(b, MSD, 16, 1000000) }
+
+func benchmarkDigits(b *testing.B, algo Algorithm, bits int, size int) {
+	s := &Sorter{Algorithm: algo, DigitBits: bits}
+	benchmarkInt64(b, s.Int64, size)
+}
@@ -0,0 +1,174 @@
+package radixsort
+
+// Uint128 is a 128bits unsigned int, such as a hash, made of its high and low
+// 64bits halves.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// FixedWidth is the set of fixed width byte arrays sorted by FixedBytes, such
+// as IPv4 addresses, MAC addresses, UUIDs, IPv6 addresses and SHA digests.
+// Defined types such as `type UUID [16]byte` are part of the set. Go generics
+// cannot abstract over array lengths, hence the list of common widths.
+type FixedWidth interface {
+	~[4]byte | ~[6]byte | ~[8]byte | ~[12]byte | ~[16]byte | ~[20]byte |
+		~[24]byte | ~[28]byte | ~[32]byte | ~[48]byte | ~[64]byte
+}
+
+// Most significant digit radix sort for Uint128, in increasing order of Hi and
+// then of Lo, over 16 digits.
+func Uint128s(xs []Uint128) {
+	if len(xs) <= 64 {
+		uint128_insertion(xs, 0)
+		return
+	}
+	fixed_msd(xs, &fixed_order[Uint128]{15, uint128_digits, uint128_insertion})
+}
+
+// Most significant digit radix sort for fixed width byte arrays, in the same
+// order as bytes.Compare, such as [16]byte UUIDs.
+func FixedBytes[T FixedWidth](xs []T) {
+	if len(xs) <= 64 {
+		fixed_insertion(xs, 0)
+		return
+	}
+	var zero T
+	fixed_msd(xs, &fixed_order[T]{len(zero) - 1, fixed_digits[T], fixed_insertion[T]})
+}
+
+// fixed_order tells fixed_most_significant_digit how to read the byte digits
+// of elements of type E: last is the depth of the last digit, digits sets ds[i]
+// to the digit of xs[i] at depth, and insertion sorts xs whose elements share
+// their digits before depth.
+type fixed_order[E any] struct {
+	last      int
+	digits    func(xs []E, ds []uint8, depth int)
+	insertion func(xs []E, depth int)
+}
+
+// fixed_msd sorts xs with fixed_most_significant_digit, using the counter width
+// suited to the length of xs.
+func fixed_msd[E any](xs []E, o *fixed_order[E]) {
+	var (
+		temp = make([]E, len(xs))
+		ds   = make([]uint8, len(xs))
+	)
+	if counter_wide(len(xs)) {
+		fixed_most_significant_digit(xs, temp, ds, o, 0, uint64(100))
+		return
+	}
+	fixed_most_significant_digit(xs, temp, ds, o, 0, uint32(100))
+}
+
+// fixed_most_significant_digit sorts xs, which all have the same first depth
+// digits, on the digit at depth, and recurses into buckets like
+// int64_most_significant_digit. Digits of xs are read by o into ds, once per
+// digit position. Digits shared by all elements are skipped without moving
+// elements. Scattering keeps the input order of elements with equal digits.
+// Buckets of cutoff elements or less are sorted by o.insertion.
+func fixed_most_significant_digit[E any, C counter](xs, temp []E, ds []uint8, o *fixed_order[E], depth int, cutoff C) {
+	var cs, is [256]C
+	for {
+		o.digits(xs, ds, depth)
+		for _, r := range ds {
+			cs[r]++
+		}
+		if cs[ds[0]] < C(len(xs)) {
+			break
+		}
+		// all elements share the digit at depth, no need to move them
+		if depth == o.last {
+			return
+		}
+		cs[ds[0]] = 0
+		depth++
+	}
+
+	a := C(0)
+	for i, c := range cs {
+		is[i] = a
+		a += c
+	}
+	for i, r := range ds {
+		temp[is[r]] = xs[i]
+		is[r]++
+	}
+	copy(xs, temp)
+
+	if depth == o.last { // that was the last radix digit
+		return
+	}
+
+	var lo C
+	for _, c := range cs {
+		hi := lo + c
+		zs := xs[lo:hi]
+
+		switch {
+		case c < 2: // already sorted
+		case c <= cutoff:
+			o.insertion(zs, depth+1)
+		default:
+			fixed_most_significant_digit(zs, temp, ds[lo:hi], o, depth+1, cutoff)
+		}
+		lo = hi
+	}
+}
+
+// uint128_digits sets ds[i] to the byte of xs[i] at depth, from the most
+// significant byte of Hi at depth 0 to the least significant byte of Lo at
+// depth 15.
+func uint128_digits(xs []Uint128, ds []uint8, depth int) {
+	shift := 56 - 8*uint(depth&7)
+	if depth < 8 {
+		for i, x := range xs {
+			ds[i] = uint8(x.Hi >> shift)
+		}
+		return
+	}
+	for i, x := range xs {
+		ds[i] = uint8(x.Lo >> shift)
+	}
+}
+
+// uint128_insertion sorts xs. Elements sharing their first depth bytes need no
+// other comparison than the one of all their bits.
+func uint128_insertion(xs []Uint128, depth int) {
+	for i := 1; i < len(xs); i++ {
+		j, x := i, xs[i]
+		for j > 0 && (x.Hi < xs[j-1].Hi || x.Hi == xs[j-1].Hi && x.Lo < xs[j-1].Lo) {
+			xs[j] = xs[j-1]
+			j--
+		}
+		xs[j] = x
+	}
+}
+
+// fixed_digits sets ds[i] to the byte of xs[i] at depth.
+func fixed_digits[T FixedWidth](xs []T, ds []uint8, depth int) {
+	for i := range xs {
+		ds[i] = xs[i][depth]
+	}
+}
+
+// fixed_insertion sorts xs, which all have the same first depth bytes.
+func fixed_insertion[T FixedWidth](xs []T, depth int) {
+	for i := 1; i < len(xs); i++ {
+		j, x := i, xs[i]
+		for j > 0 && fixed_less(&x, &xs[j-1], depth) {
+			xs[j] = xs[j-1]
+			j--
+		}
+		xs[j] = x
+	}
+}
+
+// fixed_less compares a and b from depth onwards.
+func fixed_less[T FixedWidth](a, b *T, depth int) bool {
+	for i := depth; i < len(*a); i++ {
+		if (*a)[i] != (*b)[i] {
+			return (*a)[i] < (*b)[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package radixsort
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"testing"
+)
+
+type uuid [16]byte
+
+func TestUint128Sorting(t *testing.T) {
+	sizes := []int{0, 1, 2, 10, 1e2, 1e3, 1e4, 1e5}
+	for _, size := range sizes {
+		for _, mask := range []Uint128{{^uint64(0), ^uint64(0)}, {0, ^uint64(0)}, {0xFF00, 0xFF00FF}} {
+			xs := uint128_pop(size)
+			for i := range xs {
+				xs[i].Hi &= mask.Hi
+				xs[i].Lo &= mask.Lo
+			}
+			want := slices.Clone(xs)
+			slices.SortFunc(want, uint128_compare)
+			Uint128s(xs)
+			if !slices.Equal(xs, want) {
+				t.Errorf("array of size %d with mask %x was not correctly sorted by Uint128s", size, mask)
+			}
+		}
+	}
+}
+
+func TestFixedBytesSorting(t *testing.T) {
+	sizes := []int{0, 1, 2, 10, 1e2, 1e3, 1e4, 1e5}
+	for _, size := range sizes {
+		fixed_check[uuid](t, "uuid", size)
+		fixed_check[[4]byte](t, "[4]byte", size)
+		fixed_check[[6]byte](t, "[6]byte", size)
+		fixed_check[[32]byte](t, "[32]byte", size)
+		fixed_check[[64]byte](t, "[64]byte", size)
+	}
+}
+
+func Benchmark_Uint128_RadixMSD_100000(b *testing.B) { benchmarkUint128(b, Uint128s) }
+func Benchmark_Uint128_StandardSort_100000(b *testing.B) {
+	benchmarkUint128(b, func(xs []Uint128) { slices.SortFunc(xs, uint128_compare) })
+}
+
+func Benchmark_UUID_RadixMSD_100000(b *testing.B) { benchmarkUUID(b, FixedBytes[uuid]) }
+func Benchmark_UUID_StandardSort_100000(b *testing.B) {
+	benchmarkUUID(b, func(xs []uuid) {
+		slices.SortFunc(xs, func(x, y uuid) int { return bytes.Compare(x[:], y[:]) })
+	})
+}
+
+func benchmarkUint128(b *testing.B, sorter func([]Uint128)) {
+	ys := make([][]Uint128, b.N)
+	for n := range ys {
+		ys[n] = uint128_pop(100000)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+func benchmarkUUID(b *testing.B, sorter func([]uuid)) {
+	ys := make([][]uuid, b.N)
+	for n := range ys {
+		ys[n] = fixed_pop[uuid](100000)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+func uint128_pop(size int) []Uint128 {
+	xs := make([]Uint128, size)
+	for i := range xs {
+		xs[i] = Uint128{g.next(), g.next()}
+	}
+	return xs
+}
+
+func uint128_compare(x, y Uint128) int {
+	return compare_or(cmp.Compare(x.Hi, y.Hi), cmp.Compare(x.Lo, y.Lo))
+}
+
+// fixed_pop returns size random arrays of T. Arrays of 16 bytes or more share
+// a prefix of 8 bytes out of 4 values, like UUIDs of few generators.
+func fixed_pop[T FixedWidth](size int) []T {
+	xs := make([]T, size)
+	for i := range xs {
+		var r, prefix uint64 = 0, g.next() % 4
+		for j := 0; j < len(xs[i]); j++ {
+			if j%8 == 0 {
+				r = g.next()
+			}
+			xs[i][j] = byte(r >> (8 * (j % 8)))
+			if len(xs[i]) >= 16 && j < 8 {
+				xs[i][j] = byte(prefix >> (56 - 8*j))
+			}
+		}
+	}
+	return xs
+}
+
+func fixed_check[T FixedWidth](t *testing.T, name string, size int) {
+	var (
+		xs   = fixed_pop[T](size)
+		want = slices.Clone(xs)
+	)
+	slices.SortFunc(want, func(x, y T) int {
+		return bytes.Compare(fixed_bytes(&x), fixed_bytes(&y))
+	})
+	FixedBytes(xs)
+	if !slices.Equal(xs, want) {
+		t.Errorf("array of size %d was not correctly sorted by FixedBytes[%s]", size, name)
+	}
+}
+
+// fixed_bytes returns the bytes of *x as a slice.
+func fixed_bytes[T FixedWidth](x *T) []byte {
+	bs := make([]byte, len(*x))
+	for i := range bs {
+		bs[i] = (*x)[i]
+	}
+	return bs
+}
@@ -0,0 +1,48 @@
+package radixsort
+
+// Radix sort for float32. Float32 delegates to least significant digit radix sort.
+// The order matches sort.Float64s: NaNs are placed first, -0 and +0 compare
+// equal and infinities are placed at both ends of the non-NaN values.
+func Float32(xs []float32) { Float32LSD(xs) }
+
+// Most significant digit radix sort for float32, NaNs first.
+func Float32MSD(xs []float32) { float32_sort(xs, Uint32MSD, nans_first) }
+
+// Least significant digit radix sort for float32, NaNs first.
+func Float32LSD(xs []float32) { float32_sort(xs, Uint32LSD, nans_first) }
+
+// Radix sort for float32 in decreasing order, the reverse of Float32: NaNs are
+// placed last. Float32Desc delegates to least significant digit radix sort.
+func Float32Desc(xs []float32) { Float32LSDDesc(xs) }
+
+// Most significant digit radix sort for float32 in decreasing order, NaNs last.
+func Float32MSDDesc(xs []float32) { float32_sort(xs, Uint32MSDDesc, nans_last) }
+
+// Least significant digit radix sort for float32 in decreasing order, NaNs last.
+func Float32LSDDesc(xs []float32) { float32_sort(xs, Uint32LSDDesc, nans_last) }
+
+// Radix sort for float32 following the IEEE-754 totalOrder predicate:
+// -NaN < -Inf < ... < -0 < +0 < ... < +Inf < +NaN.
+// NaNs are ordered by sign and then by payload, and -0 is placed before +0.
+func Float32TotalOrder(xs []float32) { float32_sort(xs, Uint32LSD, nans_ordered) }
+
+func float32_sort(xs []float32, sorter func([]uint32), nans float_nans) {
+	xs = float_nans_apart(xs, nans)
+	float32_as(xs, func(us []uint32) {
+		for i, u := range us {
+			us[i] = float32_key(u)
+		}
+		sorter(us)
+		for i, u := range us {
+			us[i] = float32_unkey(u)
+		}
+	})
+}
+
+func float32_key(u uint32) uint32 {
+	return u ^ (uint32(int32(u)>>31) | 1<<31)
+}
+
+func float32_unkey(u uint32) uint32 {
+	return u ^ (uint32(int32(^u)>>31) | 1<<31)
+}
@@ -0,0 +1,115 @@
+package radixsort
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestFloat32Sorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]float32){
+			"float32 radix sort MSD": Float32MSD,
+			"float32 radix sort LSD": Float32LSD,
+			"float32 standard sort":  float32_stdSort,
+		}
+	)
+	for _, size := range sizes {
+		xs := float32_pop(size)
+		for desc, s := range sorter {
+			ys := make([]float32, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.IsSorted(byFloat32(ys)) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+		ys := make([]float32, size)
+		copy(ys, xs)
+		Float32TotalOrder(ys)
+		for i := 1; i < len(ys); i++ {
+			if float32_key(math.Float32bits(ys[i-1])) > float32_key(math.Float32bits(ys[i])) {
+				t.Errorf("array of size %d was not in total order at index %d: %v > %v", size, i, ys[i-1], ys[i])
+				break
+			}
+		}
+	}
+}
+
+func TestFloat32DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]float32){
+			"float32 radix sort desc":     Float32Desc,
+			"float32 radix sort MSD desc": Float32MSDDesc,
+			"float32 radix sort LSD desc": Float32LSDDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := float32_pop(size)
+		for desc, s := range sorter {
+			ys := make([]float32, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.IsSorted(sort.Reverse(byFloat32(ys))) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func Benchmark_Float32_RadixMSD_100(b *testing.B)    { benchmarkFloat32(b, Float32MSD, 100) }
+func Benchmark_Float32_RadixMSD_1000(b *testing.B)   { benchmarkFloat32(b, Float32MSD, 1000) }
+func Benchmark_Float32_RadixMSD_10000(b *testing.B)  { benchmarkFloat32(b, Float32MSD, 10000) }
+func Benchmark_Float32_RadixMSD_100000(b *testing.B) { benchmarkFloat32(b, Float32MSD, 100000) }
+
+func Benchmark_Float32_RadixLSD_100(b *testing.B)    { benchmarkFloat32(b, Float32LSD, 100) }
+func Benchmark_Float32_RadixLSD_1000(b *testing.B)   { benchmarkFloat32(b, Float32LSD, 1000) }
+func Benchmark_Float32_RadixLSD_10000(b *testing.B)  { benchmarkFloat32(b, Float32LSD, 10000) }
+func Benchmark_Float32_RadixLSD_100000(b *testing.B) { benchmarkFloat32(b, Float32LSD, 100000) }
+
+func Benchmark_Float32_StandardSort_100(b *testing.B)   { benchmarkFloat32(b, float32_stdSort, 100) }
+func Benchmark_Float32_StandardSort_1000(b *testing.B)  { benchmarkFloat32(b, float32_stdSort, 1000) }
+func Benchmark_Float32_StandardSort_10000(b *testing.B) { benchmarkFloat32(b, float32_stdSort, 10000) }
+func Benchmark_Float32_StandardSort_100000(b *testing.B) {
+	benchmarkFloat32(b, float32_stdSort, 100000)
+}
+
+func benchmarkFloat32(b *testing.B, sorter func([]float32), size int) {
+	ys := make([][]float32, b.N)
+	for n := range ys {
+		ys[n] = float32_pop(size)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+func float32_stdSort(xs []float32) {
+	sort.Sort(byFloat32(xs))
+}
+
+func float32_pop(size int) []float32 {
+	specials := []float32{float32(math.NaN()), float32(math.Inf(1)), float32(math.Inf(-1)), 0, float32(math.Copysign(0, -1))}
+	xs := make([]float32, size)
+	for i := range xs {
+		u := g.next()
+		if u%64 == 0 {
+			xs[i] = specials[(u>>8)%uint64(len(specials))]
+		} else {
+			xs[i] = math.Float32frombits(uint32(u))
+		}
+	}
+	return xs
+}
+
+// byFloat32 orders NaNs first, like sort.Float64Slice.
+type byFloat32 []float32
+
+func (xs byFloat32) Len() int      { return len(xs) }
+func (xs byFloat32) Swap(i, j int) { xs[i], xs[j] = xs[j], xs[i] }
+func (xs byFloat32) Less(i, j int) bool {
+	return xs[i] < xs[j] || (xs[i] != xs[i] && xs[j] == xs[j])
+}
@@ -0,0 +1,88 @@
+package radixsort
+
+// Radix sort for float64. Float64 delegates to most significant digit radix sort.
+// The order matches sort.Float64s: NaNs are placed first, -0 and +0 compare
+// equal and infinities are placed at both ends of the non-NaN values.
+func Float64(xs []float64) { Float64MSD(xs) }
+
+// Most significant digit radix sort for float64, NaNs first.
+func Float64MSD(xs []float64) { float64_sort(xs, Uint64MSD, nans_first) }
+
+// Least significant digit radix sort for float64, NaNs first.
+func Float64LSD(xs []float64) { float64_sort(xs, Uint64LSD, nans_first) }
+
+// Radix sort for float64 in decreasing order, the reverse of Float64: NaNs are
+// placed last. Float64Desc delegates to most significant digit radix sort.
+func Float64Desc(xs []float64) { Float64MSDDesc(xs) }
+
+// Most significant digit radix sort for float64 in decreasing order, NaNs last.
+func Float64MSDDesc(xs []float64) { float64_sort(xs, Uint64MSDDesc, nans_last) }
+
+// Least significant digit radix sort for float64 in decreasing order, NaNs last.
+func Float64LSDDesc(xs []float64) { float64_sort(xs, Uint64LSDDesc, nans_last) }
+
+// Radix sort for float64 following the IEEE-754 totalOrder predicate:
+// -NaN < -Inf < ... < -0 < +0 < ... < +Inf < +NaN.
+// NaNs are ordered by sign and then by payload, and -0 is placed before +0.
+func Float64TotalOrder(xs []float64) { float64_sort(xs, Uint64MSD, nans_ordered) }
+
+// float_nans tells float sorts where to place NaNs.
+type float_nans int
+
+const (
+	nans_ordered float_nans = iota // NaNs are sorted with their keys, as totalOrder
+	nans_first                     // NaNs are moved to the front of the array
+	nans_last                      // NaNs are moved to the back of the array
+)
+
+// float_nans_apart moves the NaNs of xs to its front or back as set by nans,
+// and returns the other elements, which are left to sort.
+func float_nans_apart[F ~float32 | ~float64](xs []F, nans float_nans) []F {
+	switch nans {
+	case nans_first:
+		n := 0
+		for i, x := range xs {
+			if x != x {
+				xs[i], xs[n] = xs[n], x
+				n++
+			}
+		}
+		return xs[n:]
+	case nans_last:
+		n := len(xs)
+		for i := len(xs) - 1; i >= 0; i-- {
+			if x := xs[i]; x != x {
+				n--
+				xs[i], xs[n] = xs[n], x
+			}
+		}
+		return xs[:n]
+	}
+	return xs
+}
+
+// float64_sort maps floats to uint64 keys ordered like totalOrder, sorts the
+// keys as unsigned ints and maps them back. NaNs are first moved apart as set
+// by nans.
+func float64_sort(xs []float64, sorter func([]uint64), nans float_nans) {
+	xs = float_nans_apart(xs, nans)
+	float64_as(xs, func(us []uint64) {
+		for i, u := range us {
+			us[i] = float64_key(u)
+		}
+		sorter(us)
+		for i, u := range us {
+			us[i] = float64_unkey(u)
+		}
+	})
+}
+
+// float64_key flips all bits of negative floats and the sign bit of positive
+// floats, so that unsigned order of keys is the totalOrder of floats.
+func float64_key(u uint64) uint64 {
+	return u ^ (uint64(int64(u)>>63) | 1<<63)
+}
+
+func float64_unkey(u uint64) uint64 {
+	return u ^ (uint64(int64(^u)>>63) | 1<<63)
+}
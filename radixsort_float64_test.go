@@ -0,0 +1,140 @@
+package radixsort
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestFloat64Sorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]float64){
+			"float64 radix sort MSD": Float64MSD,
+			"float64 radix sort LSD": Float64LSD,
+			"float64 standard sort":  sort.Float64s,
+		}
+	)
+	for _, size := range sizes {
+		xs := float64_pop(size)
+		for desc, s := range sorter {
+			ys := make([]float64, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.Float64sAreSorted(ys) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+		ys := make([]float64, size)
+		copy(ys, xs)
+		Float64TotalOrder(ys)
+		for i := 1; i < len(ys); i++ {
+			if float64_key(math.Float64bits(ys[i-1])) > float64_key(math.Float64bits(ys[i])) {
+				t.Errorf("array of size %d was not in total order at index %d: %v > %v", size, i, ys[i-1], ys[i])
+				break
+			}
+		}
+	}
+}
+
+func TestFloat64DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]float64){
+			"float64 radix sort desc":     Float64Desc,
+			"float64 radix sort MSD desc": Float64MSDDesc,
+			"float64 radix sort LSD desc": Float64LSDDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := float64_pop(size)
+		for desc, s := range sorter {
+			ys := make([]float64, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.IsSorted(sort.Reverse(sort.Float64Slice(ys))) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func TestFloat64SpecialValues(t *testing.T) {
+	var (
+		negNaN = math.Float64frombits(1<<63 | math.Float64bits(math.NaN()))
+		negZ   = math.Copysign(0, -1)
+		xs     = []float64{1, math.Inf(1), negZ, math.NaN(), -1, 0, math.Inf(-1), negNaN}
+	)
+	ys := make([]float64, len(xs))
+	copy(ys, xs)
+	Float64TotalOrder(ys)
+	want := []uint64{
+		math.Float64bits(negNaN),
+		math.Float64bits(math.Inf(-1)),
+		math.Float64bits(-1),
+		math.Float64bits(negZ),
+		math.Float64bits(0),
+		math.Float64bits(1),
+		math.Float64bits(math.Inf(1)),
+		math.Float64bits(math.NaN()),
+	}
+	for i, y := range ys {
+		if math.Float64bits(y) != want[i] {
+			t.Errorf("total order: got %v at index %d, want %v", y, i, math.Float64frombits(want[i]))
+		}
+	}
+
+	copy(ys, xs)
+	Float64(ys)
+	if !math.IsNaN(ys[0]) || !math.IsNaN(ys[1]) || ys[2] != math.Inf(-1) || ys[7] != math.Inf(1) {
+		t.Errorf("NaNs were not placed first: %v", ys)
+	}
+
+	copy(ys, xs)
+	Float64Desc(ys)
+	if !math.IsNaN(ys[6]) || !math.IsNaN(ys[7]) || ys[0] != math.Inf(1) || ys[5] != math.Inf(-1) {
+		t.Errorf("NaNs were not placed last: %v", ys)
+	}
+}
+
+func Benchmark_Float64_RadixMSD_100(b *testing.B)    { benchmarkFloat64(b, Float64MSD, 100) }
+func Benchmark_Float64_RadixMSD_1000(b *testing.B)   { benchmarkFloat64(b, Float64MSD, 1000) }
+func Benchmark_Float64_RadixMSD_10000(b *testing.B)  { benchmarkFloat64(b, Float64MSD, 10000) }
+func Benchmark_Float64_RadixMSD_100000(b *testing.B) { benchmarkFloat64(b, Float64MSD, 100000) }
+
+func Benchmark_Float64_RadixLSD_100(b *testing.B)    { benchmarkFloat64(b, Float64LSD, 100) }
+func Benchmark_Float64_RadixLSD_1000(b *testing.B)   { benchmarkFloat64(b, Float64LSD, 1000) }
+func Benchmark_Float64_RadixLSD_10000(b *testing.B)  { benchmarkFloat64(b, Float64LSD, 10000) }
+func Benchmark_Float64_RadixLSD_100000(b *testing.B) { benchmarkFloat64(b, Float64LSD, 100000) }
+
+func Benchmark_Float64_StandardSort_100(b *testing.B)    { benchmarkFloat64(b, sort.Float64s, 100) }
+func Benchmark_Float64_StandardSort_1000(b *testing.B)   { benchmarkFloat64(b, sort.Float64s, 1000) }
+func Benchmark_Float64_StandardSort_10000(b *testing.B)  { benchmarkFloat64(b, sort.Float64s, 10000) }
+func Benchmark_Float64_StandardSort_100000(b *testing.B) { benchmarkFloat64(b, sort.Float64s, 100000) }
+
+func benchmarkFloat64(b *testing.B, sorter func([]float64), size int) {
+	ys := make([][]float64, b.N)
+	for n := range ys {
+		ys[n] = float64_pop(size)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+// float64_pop mixes random bit patterns, which covers all exponents, with
+// infinities, zeros and NaNs of both signs.
+func float64_pop(size int) []float64 {
+	specials := []float64{math.NaN(), math.Inf(1), math.Inf(-1), 0, math.Copysign(0, -1)}
+	xs := make([]float64, size)
+	for i := range xs {
+		u := g.next()
+		if u%64 == 0 {
+			xs[i] = specials[(u>>8)%uint64(len(specials))]
+		} else {
+			xs[i] = math.Float64frombits(u)
+		}
+	}
+	return xs
+}
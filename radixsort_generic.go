@@ -0,0 +1,142 @@
+package radixsort
+
+// Integer is the set of integer types accepted by Sort, SortMSD and SortLSD.
+// Defined types such as `type UserID int64` are part of the set.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// word32 is the set of element types sorted by the 32bits kernels.
+type word32 interface {
+	~int32 | ~uint32
+}
+
+// word64 is the set of element types sorted by the 64bits kernels.
+type word64 interface {
+	~int64 | ~uint64
+}
+
+// Radix sort for any integer type. Sort delegates to the same implementation
+// as the non generic wrapper for the width of T: counting sort for 8bits
+// types, Int16 and Uint16 for 16bits types, Int32 and Uint32 for 32bits types
+// and Int64 and Uint64 for 64bits types.
+func Sort[T Integer](xs []T) {
+	signed := integer_signed[T]()
+	switch integer_size[T]() {
+	case 8:
+		if signed {
+			integer_as(xs, Int64)
+		} else {
+			integer_as(xs, Uint64)
+		}
+	case 4:
+		if signed {
+			integer_as(xs, Int32)
+		} else {
+			integer_as(xs, Uint32)
+		}
+	case 2:
+		if signed {
+			integer_as(xs, Int16)
+		} else {
+			integer_as(xs, Uint16)
+		}
+	default:
+		if signed {
+			integer_as(xs, Int8)
+		} else {
+			integer_as(xs, Uint8)
+		}
+	}
+}
+
+// Most significant digit radix sort for any integer type. 8bits and 16bits
+// types are sorted by counting sort, which has a single digit.
+func SortMSD[T Integer](xs []T) {
+	signed := integer_signed[T]()
+	switch integer_size[T]() {
+	case 8:
+		if signed {
+			integer_as(xs, Int64MSD)
+		} else {
+			integer_as(xs, Uint64MSD)
+		}
+	case 4:
+		if signed {
+			integer_as(xs, Int32MSD)
+		} else {
+			integer_as(xs, Uint32MSD)
+		}
+	case 2:
+		if signed {
+			integer_as(xs, Int16MSD)
+		} else {
+			integer_as(xs, Uint16MSD)
+		}
+	default:
+		if signed {
+			integer_as(xs, Int8MSD)
+		} else {
+			integer_as(xs, Uint8MSD)
+		}
+	}
+}
+
+// Least significant digit radix sort for any integer type. 8bits types are
+// sorted by counting sort.
+func SortLSD[T Integer](xs []T) {
+	signed := integer_signed[T]()
+	switch integer_size[T]() {
+	case 8:
+		if signed {
+			integer_as(xs, Int64LSD)
+		} else {
+			integer_as(xs, Uint64LSD)
+		}
+	case 4:
+		if signed {
+			integer_as(xs, Int32LSD)
+		} else {
+			integer_as(xs, Uint32LSD)
+		}
+	case 2:
+		if signed {
+			integer_as(xs, Int16LSD)
+		} else {
+			integer_as(xs, Uint16LSD)
+		}
+	default:
+		if signed {
+			integer_as(xs, Int8LSD)
+		} else {
+			integer_as(xs, Uint8LSD)
+		}
+	}
+}
+
+func integer_signed[T Integer]() bool {
+	return ^T(0) < 0
+}
+
+func integer_insertion[T Integer](xs []T) {
+	for i := 1; i < len(xs); i++ {
+		j, x := i, xs[i]
+		for j > 0 && xs[j-1] > x {
+			xs[j] = xs[j-1]
+			j--
+		}
+		xs[j] = x
+	}
+}
+
+func integer_insertion_desc[T Integer](xs []T) {
+	for i := 1; i < len(xs); i++ {
+		j, x := i, xs[i]
+		for j > 0 && xs[j-1] < x {
+			xs[j] = xs[j-1]
+			j--
+		}
+		xs[j] = x
+	}
+}
@@ -0,0 +1,68 @@
+package radixsort
+
+import (
+	"sort"
+	"testing"
+)
+
+type userID int64
+type port uint16
+type flags uint32
+
+func TestGenericSorting(t *testing.T) {
+	sizes := []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+	for _, size := range sizes {
+		generic_check[int](t, "int", size)
+		generic_check[uint](t, "uint", size)
+		generic_check[int8](t, "int8", size)
+		generic_check[uint8](t, "uint8", size)
+		generic_check[int16](t, "int16", size)
+		generic_check[port](t, "port", size)
+		generic_check[int32](t, "int32", size)
+		generic_check[flags](t, "flags", size)
+		generic_check[userID](t, "userID", size)
+		generic_check[uint64](t, "uint64", size)
+		generic_check[uintptr](t, "uintptr", size)
+	}
+}
+
+func Benchmark_UserID_Sort_100000(b *testing.B) { benchmarkUserID(b, Sort[userID], 100000) }
+func Benchmark_UserID_SortLSD_100000(b *testing.B) {
+	benchmarkUserID(b, SortLSD[userID], 100000)
+}
+
+func benchmarkUserID(b *testing.B, sorter func([]userID), size int) {
+	ys := make([][]userID, b.N)
+	for n := range ys {
+		ys[n] = generic_pop[userID](size)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+func generic_check[T Integer](t *testing.T, name string, size int) {
+	sorter := map[string]func([]T){
+		"radix sort":     Sort[T],
+		"radix sort MSD": SortMSD[T],
+		"radix sort LSD": SortLSD[T],
+	}
+	xs := generic_pop[T](size)
+	for desc, s := range sorter {
+		ys := make([]T, size)
+		copy(ys, xs)
+		s(ys)
+		if !sort.SliceIsSorted(ys, func(i, j int) bool { return ys[i] < ys[j] }) {
+			t.Errorf("array of size %d was not correctly sorted by %s %s", size, name, desc)
+		}
+	}
+}
+
+func generic_pop[T Integer](size int) []T {
+	xs := make([]T, size)
+	for i := range xs {
+		xs[i] = T(g.next())
+	}
+	return xs
+}
@@ -1,17 +1,45 @@
 package radixsort
 
 import (
-	"unsafe"
+	"math/bits"
 )
 
-// Radix sort for int. Int delegates to int64 most significant digit radix sort.
-// Only works on 64bits architectures.
+// Radix sort for int. Int delegates to Int64 on 64bits architectures, and to
+// Int32 on 32bits architectures.
 func Int(xs []int) {
-	Int64MSD(*(*[]int64)(unsafe.Pointer(&xs)))
+	if bits.UintSize == 64 {
+		integer_as(xs, Int64)
+	} else {
+		integer_as(xs, Int32)
+	}
 }
 
-// Radix sort for uint. Uint delegates to uint64 most significant digit radix sort.
-// Only works on 64bits architectures.
+// Radix sort for uint. Uint delegates to Uint64 on 64bits architectures, and
+// to Uint32 on 32bits architectures.
 func Uint(xs []uint) {
-	Uint64MSD(*(*[]uint64)(unsafe.Pointer(&xs)))
+	if bits.UintSize == 64 {
+		integer_as(xs, Uint64)
+	} else {
+		integer_as(xs, Uint32)
+	}
+}
+
+// Radix sort for int in decreasing order. IntDesc delegates to Int64Desc on
+// 64bits architectures, and to Int32Desc on 32bits architectures.
+func IntDesc(xs []int) {
+	if bits.UintSize == 64 {
+		integer_as(xs, Int64Desc)
+	} else {
+		integer_as(xs, Int32Desc)
+	}
+}
+
+// Radix sort for uint in decreasing order. UintDesc delegates to Uint64Desc on
+// 64bits architectures, and to Uint32Desc on 32bits architectures.
+func UintDesc(xs []uint) {
+	if bits.UintSize == 64 {
+		integer_as(xs, Uint64Desc)
+	} else {
+		integer_as(xs, Uint32Desc)
+	}
 }
@@ -0,0 +1,306 @@
+package radixsort
+
+// Radix sort for int16. Int16 delegates to least significant digit radix sort
+// for arrays of less than 2^18 elements, and to counting sort above, where the
+// cost of its table of 65536 counters is amortized.
+func Int16(xs []int16) {
+	if len(xs) < 1<<18 {
+		Int16LSD(xs)
+		return
+	}
+	Int16Counting(xs)
+}
+
+// Radix sort for uint16. Uint16 delegates to least significant digit radix sort
+// for arrays of less than 2^18 elements, and to counting sort above, where the
+// cost of its table of 65536 counters is amortized.
+func Uint16(xs []uint16) {
+	if len(xs) < 1<<18 {
+		Uint16LSD(xs)
+		return
+	}
+	Uint16Counting(xs)
+}
+
+// Least significant digit radix sort for int16, in two passes.
+func Int16LSD(xs []int16) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint16_lsd(xs, 1<<15, 0)
+}
+
+// Least significant digit radix sort for uint16, in two passes.
+func Uint16LSD(xs []uint16) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint16_lsd(xs, 0, 0)
+}
+
+// Counting sort for int16. The values of xs are counted in a table of 65536
+// counters, then xs is rewritten from the counts, without swap space.
+func Int16Counting(xs []int16) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint16_counting(xs, 1<<15, 0)
+}
+
+// Counting sort for uint16. The values of xs are counted in a table of 65536
+// counters, then xs is rewritten from the counts, without swap space.
+func Uint16Counting(xs []uint16) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint16_counting(xs, 0, 0)
+}
+
+// Radix sort for int16 in decreasing order. Int16Desc delegates to least
+// significant digit radix sort, or to counting sort for arrays of 2^18 elements
+// or more, like Int16.
+func Int16Desc(xs []int16) {
+	if len(xs) < 1<<18 {
+		Int16LSDDesc(xs)
+		return
+	}
+	Int16CountingDesc(xs)
+}
+
+// Radix sort for uint16 in decreasing order. Uint16Desc delegates to least
+// significant digit radix sort, or to counting sort for arrays of 2^18 elements
+// or more, like Uint16.
+func Uint16Desc(xs []uint16) {
+	if len(xs) < 1<<18 {
+		Uint16LSDDesc(xs)
+		return
+	}
+	Uint16CountingDesc(xs)
+}
+
+// Least significant digit radix sort for int16 in decreasing order.
+func Int16LSDDesc(xs []int16) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint16_lsd(xs, 1<<15, 0xFF)
+}
+
+// Least significant digit radix sort for uint16 in decreasing order.
+func Uint16LSDDesc(xs []uint16) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint16_lsd(xs, 0, 0xFF)
+}
+
+// Counting sort for int16 in decreasing order.
+func Int16CountingDesc(xs []int16) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint16_counting(xs, 1<<15, 0xFF)
+}
+
+// Counting sort for uint16 in decreasing order.
+func Uint16CountingDesc(xs []uint16) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint16_counting(xs, 0, 0xFF)
+}
+
+// Most significant digit radix sort for int16, on the high byte and then on
+// the low byte of buckets of more than 100 elements.
+func Int16MSD(xs []int16) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint16_msd(xs, 1<<15, 0)
+}
+
+// Most significant digit radix sort for uint16, on the high byte and then on
+// the low byte of buckets of more than 100 elements.
+func Uint16MSD(xs []uint16) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint16_msd(xs, 0, 0)
+}
+
+// Most significant digit radix sort for int16 in decreasing order.
+func Int16MSDDesc(xs []int16) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint16_msd(xs, 1<<15, 0xFF)
+}
+
+// Most significant digit radix sort for uint16 in decreasing order.
+func Uint16MSDDesc(xs []uint16) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint16_msd(xs, 0, 0xFF)
+}
+
+// uint16_msd sorts xs with uint16_most_significant_digit, using the counter
+// width suited to the length of xs.
+func uint16_msd[T ~int16 | ~uint16](xs []T, flip uint16, mask int) {
+	temp := make([]T, len(xs))
+	if counter_wide(len(xs)) {
+		uint16_most_significant_digit(xs, temp, flip, 8, mask, uint64(100))
+		return
+	}
+	uint16_most_significant_digit(xs, temp, flip, 8, mask, uint32(100))
+}
+
+// uint16_most_significant_digit sorts xs on the digit at shift of values
+// xored with flip, like int64_most_significant_digit, and sorts the buckets of
+// the high byte on the low byte, or by insertion sort once they have cutoff
+// elements or less. Buckets are laid out in increasing order of digits if mask
+// is 0, and in decreasing order if mask is 0xFF.
+func uint16_most_significant_digit[T ~int16 | ~uint16, C counter](xs, temp []T, flip uint16, shift uint, mask int, cutoff C) {
+	var cs, is [256]C
+	for _, x := range xs {
+		cs[uint8((uint16(x)^flip)>>shift)]++
+	}
+	a := C(0)
+	for i := 0; i < 256; i++ {
+		is[i^mask] = a
+		a += cs[i^mask]
+	}
+	for _, x := range xs {
+		r := uint8((uint16(x) ^ flip) >> shift)
+		temp[is[r]] = x
+		is[r]++
+	}
+	copy(xs, temp)
+
+	if shift == 0 { // that was the last radix digit
+		return
+	}
+
+	var lo C
+	for i := 0; i < 256; i++ {
+		var (
+			c  = cs[i^mask]
+			hi = lo + c
+			zs = xs[lo:hi]
+		)
+		lo = hi
+
+		switch {
+		case c < 2: // already sorted
+		case c <= cutoff && mask == 0:
+			integer_insertion(zs)
+		case c <= cutoff:
+			integer_insertion_desc(zs)
+		default:
+			uint16_most_significant_digit(zs, temp, flip, 0, mask, cutoff)
+		}
+	}
+}
+
+// uint16_lsd sorts xs with uint16_least_significant_digit, using the counter
+// width suited to the length of xs.
+func uint16_lsd[T ~int16 | ~uint16](xs []T, flip uint16, mask int) {
+	ys := make([]T, len(xs))
+	if counter_wide(len(xs)) {
+		uint16_least_significant_digit[T, uint64](xs, ys, flip, mask)
+		return
+	}
+	uint16_least_significant_digit[T, uint32](xs, ys, flip, mask)
+}
+
+// uint16_least_significant_digit sorts xs using ys as swap space. Radix
+// digits are taken from the bits of values xored with flip, which is 1<<15 for
+// signed order. xs is sorted in increasing order if mask is 0, and in
+// decreasing order if mask is 0xFF. Digits shared by all elements are not
+// scattered.
+func uint16_least_significant_digit[T ~int16 | ~uint16, C counter](xs, ys []T, flip uint16, mask int) {
+	var css [2][256]C
+
+	// count all radix keys
+	for _, x := range xs {
+		k := uint16(x) ^ flip
+		css[0][k&0xFF]++
+		css[1][k>>8]++
+	}
+
+	// aggregate radix counts to radix offsets, in decreasing order of digits
+	// when mask is set, and find digits shared by all elements
+	var constant [2]bool
+	for i := range css {
+		cs := &css[i]
+		a := C(0)
+		for j := 0; j < 256; j++ {
+			c := cs[j^mask]
+			cs[j^mask] = a
+			a += c
+			constant[i] = constant[i] || c == C(len(xs))
+		}
+	}
+
+	swaps := 0
+	for i := range css {
+		if constant[i] { // scattering would leave xs unchanged
+			continue
+		}
+		var (
+			cs    = css[i] // do not obtain cs from range expr
+			shift = uint(8 * i)
+		)
+		for _, x := range xs {
+			r := ((uint16(x) ^ flip) >> shift) & 0xFF
+			j := cs[r]
+			cs[r]++
+			ys[j] = x
+		}
+		xs, ys = ys, xs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the swap space
+		copy(ys, xs)
+	}
+}
+
+// uint16_counting sorts xs by counting values with flip xored to their bits,
+// which is 1<<15 for signed order. Values are rewritten in increasing order if
+// mask is 0, and in decreasing order if mask is 0xFF, which applies to both
+// bytes of values.
+func uint16_counting[T ~int16 | ~uint16](xs []T, flip uint16, mask int) {
+	cs := make([]int, 1<<16)
+	for _, x := range xs {
+		cs[uint16(x)^flip]++
+	}
+	mask |= mask << 8
+	lo := 0
+	for j := range cs {
+		r := j ^ mask
+		c := cs[r]
+		if c == 0 {
+			continue
+		}
+		var (
+			x  = T(uint16(r) ^ flip)
+			zs = xs[lo : lo+c]
+		)
+		for i := range zs {
+			zs[i] = x
+		}
+		lo += c
+	}
+}
@@ -0,0 +1,132 @@
+package radixsort
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestInt16Sorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]int16){
+			"int16 radix sort":     Int16,
+			"int16 radix sort MSD": Int16MSD,
+			"int16 radix sort LSD": Int16LSD,
+			"int16 counting sort":  Int16Counting,
+		}
+	)
+	for _, size := range sizes {
+		xs := generic_pop[int16](size)
+		for desc, s := range sorter {
+			ys := make([]int16, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.SliceIsSorted(ys, func(i, j int) bool { return ys[i] < ys[j] }) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func TestInt16DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]int16){
+			"int16 radix sort desc":     Int16Desc,
+			"int16 radix sort MSD desc": Int16MSDDesc,
+			"int16 radix sort LSD desc": Int16LSDDesc,
+			"int16 counting sort desc":  Int16CountingDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := generic_pop[int16](size)
+		for desc, s := range sorter {
+			ys := make([]int16, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.SliceIsSorted(ys, func(i, j int) bool { return ys[i] > ys[j] }) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func TestUint16Sorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]uint16){
+			"uint16 radix sort":     Uint16,
+			"uint16 radix sort MSD": Uint16MSD,
+			"uint16 radix sort LSD": Uint16LSD,
+			"uint16 counting sort":  Uint16Counting,
+		}
+	)
+	for _, size := range sizes {
+		xs := generic_pop[uint16](size)
+		for desc, s := range sorter {
+			ys := make([]uint16, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.SliceIsSorted(ys, func(i, j int) bool { return ys[i] < ys[j] }) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func TestUint16DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]uint16){
+			"uint16 radix sort desc":     Uint16Desc,
+			"uint16 radix sort MSD desc": Uint16MSDDesc,
+			"uint16 radix sort LSD desc": Uint16LSDDesc,
+			"uint16 counting sort desc":  Uint16CountingDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := generic_pop[uint16](size)
+		for desc, s := range sorter {
+			ys := make([]uint16, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.SliceIsSorted(ys, func(i, j int) bool { return ys[i] > ys[j] }) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func Benchmark_Int16_RadixLSD_100(b *testing.B)     { benchmarkInt16(b, Int16LSD, 100) }
+func Benchmark_Int16_RadixLSD_1000(b *testing.B)    { benchmarkInt16(b, Int16LSD, 1000) }
+func Benchmark_Int16_RadixLSD_10000(b *testing.B)   { benchmarkInt16(b, Int16LSD, 10000) }
+func Benchmark_Int16_RadixLSD_100000(b *testing.B)  { benchmarkInt16(b, Int16LSD, 100000) }
+func Benchmark_Int16_Counting_100(b *testing.B)     { benchmarkInt16(b, Int16Counting, 100) }
+func Benchmark_Int16_Counting_1000(b *testing.B)    { benchmarkInt16(b, Int16Counting, 1000) }
+func Benchmark_Int16_Counting_10000(b *testing.B)   { benchmarkInt16(b, Int16Counting, 10000) }
+func Benchmark_Int16_Counting_100000(b *testing.B)  { benchmarkInt16(b, Int16Counting, 100000) }
+func Benchmark_Int16_StandardSort_100(b *testing.B) { benchmarkInt16(b, int16_stdSort, 100) }
+func Benchmark_Int16_StandardSort_1000(b *testing.B) {
+	benchmarkInt16(b, int16_stdSort, 1000)
+}
+func Benchmark_Int16_StandardSort_10000(b *testing.B) {
+	benchmarkInt16(b, int16_stdSort, 10000)
+}
+func Benchmark_Int16_StandardSort_100000(b *testing.B) {
+	benchmarkInt16(b, int16_stdSort, 100000)
+}
+
+func benchmarkInt16(b *testing.B, sorter func([]int16), size int) {
+	ys := make([][]int16, b.N)
+	for n := range ys {
+		ys[n] = generic_pop[int16](size)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+func int16_stdSort(xs []int16) {
+	sort.Slice(xs, func(i, j int) bool { return xs[i] < xs[j] })
+}
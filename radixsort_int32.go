@@ -1,70 +1,240 @@
 package radixsort
 
-import (
-	"unsafe"
-)
-
 // Radix sort for int32. Int32 delegates to least significant digit radix sort.
-func Int32(xs []int32) { Int32LSD(xs) }
+// When the values of xs lie in a narrow range, only the low bits of their
+// difference with the smallest value are sorted.
+func Int32(xs []int32) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	temp := make([]int32, len(xs))
+	if !range_sort(xs, temp, 1<<31, 32, 100, 0, nil) {
+		int32_lsd(xs, temp, 1<<7, 0, 0, nil)
+	}
+}
 
 // Radix sort for uint32. Uint32 delegates to least significant digit radix sort.
-func Uint32(xs []uint32) { Uint32LSD(xs) }
+// When the values of xs lie in a narrow range, only the low bits of their
+// difference with the smallest value are sorted.
+func Uint32(xs []uint32) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	temp := make([]uint32, len(xs))
+	if !range_sort(xs, temp, 0, 32, 100, 0, nil) {
+		int32_lsd(xs, temp, 0, 0, 0, nil)
+	}
+}
 
-// Most significant digit radix sort for int32.
+// Most significant digit radix sort for int32. Unlike Int32, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Int32MSD(xs []int32) {
 	if len(xs) <= 64 {
-		int32_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	var (
-		temp = make([]int32, len(xs))
-		is   [256]uint32
-	)
-	int32_most_significant_digit(xs, temp, &is, 1<<7, 24)
+	int32_msd(xs, make([]int32, len(xs)), 1<<7, 0, 100, 0, nil)
 }
 
-// Most significant digit radix sort for uint32.
+// Most significant digit radix sort for uint32. Unlike Uint32, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Uint32MSD(xs []uint32) {
 	if len(xs) <= 64 {
-		uint32_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	var (
-		temp = make([]int32, len(xs))
-		is   [256]uint32
-	)
-	int32_most_significant_digit(*(*[]int32)(unsafe.Pointer(&xs)), temp, &is, 0, 24)
+	int32_msd(xs, make([]uint32, len(xs)), 0, 0, 100, 0, nil)
 }
 
-// Least significant digit radix sort for int32.
+// Least significant digit radix sort for int32. Unlike Int32, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Int32LSD(xs []int32) {
 	if len(xs) <= 64 {
-		int32_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	int32_least_significant_digit(xs, 1<<7)
+	int32_lsd(xs, make([]int32, len(xs)), 1<<7, 0, 0, nil)
 }
 
-// Least significant digit radix sort for uint32.
+// Least significant digit radix sort for uint32. Unlike Uint32, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Uint32LSD(xs []uint32) {
 	if len(xs) <= 64 {
-		uint32_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	int32_least_significant_digit(*(*[]int32)(unsafe.Pointer(&xs)), 0)
+	int32_lsd(xs, make([]uint32, len(xs)), 0, 0, 0, nil)
 }
 
-func int32_most_significant_digit(xs, temp []int32, is *[256]uint32, offset int32, shift uint) {
-	var cs [256]uint32
-	for _, x := range xs {
-		r := (offset + (x >> shift)) & 0xFF
-		cs[r]++
+// In place most significant digit radix sort for int32, also known as
+// American flag sort. It uses no swap space, but is slower than Int32MSD.
+func Int32InPlace(xs []int32) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
 	}
-	a := uint32(0)
-	for i := 0; i < 256; i++ {
-		is[i] = a
-		a += cs[i]
+	int32_flag(xs, 1<<7, 100)
+}
+
+// In place most significant digit radix sort for uint32, also known as
+// American flag sort. It uses no swap space, but is slower than Uint32MSD.
+func Uint32InPlace(xs []uint32) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int32_flag(xs, 0, 100)
+}
+
+// Most significant digit radix sort for int32, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Int32MSDWithBuffer(xs, buf []int32) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int32_msd(xs, buf[:len(xs)], 1<<7, 0, 100, 0, nil)
+}
+
+// Most significant digit radix sort for uint32, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Uint32MSDWithBuffer(xs, buf []uint32) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int32_msd(xs, buf[:len(xs)], 0, 0, 100, 0, nil)
+}
+
+// Least significant digit radix sort for int32, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Int32LSDWithBuffer(xs, buf []int32) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int32_lsd(xs, buf[:len(xs)], 1<<7, 0, 0, nil)
+}
+
+// Least significant digit radix sort for uint32, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Uint32LSDWithBuffer(xs, buf []uint32) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int32_lsd(xs, buf[:len(xs)], 0, 0, 0, nil)
+}
+
+// Radix sort for int32 in decreasing order. Int32Desc delegates to
+// least significant digit radix sort.
+func Int32Desc(xs []int32) { Int32LSDDesc(xs) }
+
+// Radix sort for uint32 in decreasing order. Uint32Desc delegates to
+// least significant digit radix sort.
+func Uint32Desc(xs []uint32) { Uint32LSDDesc(xs) }
+
+// Most significant digit radix sort for int32 in decreasing order.
+func Int32MSDDesc(xs []int32) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int32_msd(xs, make([]int32, len(xs)), 1<<7, 0xFF, 100, 8, nil)
+}
+
+// Most significant digit radix sort for uint32 in decreasing order.
+func Uint32MSDDesc(xs []uint32) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int32_msd(xs, make([]uint32, len(xs)), 0, 0xFF, 100, 8, nil)
+}
+
+// Least significant digit radix sort for int32 in decreasing order.
+func Int32LSDDesc(xs []int32) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int32_lsd(xs, make([]int32, len(xs)), 1<<7, 0xFF, 8, nil)
+}
+
+// Least significant digit radix sort for uint32 in decreasing order.
+func Uint32LSDDesc(xs []uint32) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int32_lsd(xs, make([]uint32, len(xs)), 0, 0xFF, 8, nil)
+}
+
+// int32_msd sorts xs with int32_most_significant_digit, using temp as swap
+// space and the counter width suited to the length of xs. Digits are bits wide,
+// or selected from the length of xs if bits is 0. Digits wider than 8 bits are
+// sorted by digits_msd with the count tables of tables, in increasing order
+// only.
+func int32_msd[T word32](xs, temp []T, offset T, mask int, cutoff int, bits uint, tables *digits_tables) {
+	if bits == 0 {
+		bits = digit_bits_msd(len(xs))
+	}
+	if bits > 8 {
+		digits_msd(xs, temp, uint64(offset)<<24, 0, 32, bits, cutoff, tables)
+		return
+	}
+	if counter_wide(len(xs)) {
+		var is [256]uint64
+		int32_most_significant_digit(xs, temp, &is, offset, 24, mask, uint64(cutoff))
+		return
 	}
+	var is [256]uint32
+	int32_most_significant_digit(xs, temp, &is, offset, 24, mask, uint32(cutoff))
+}
+
+// int32_lsd sorts xs with int32_least_significant_digit, using ys as swap
+// space and the counter width suited to the length of xs. Digits are bits wide,
+// or selected from the length of xs if bits is 0. Digits wider than 8 bits are
+// sorted by digits_lsd with the count tables of tables, in increasing order
+// only.
+func int32_lsd[T word32](xs, ys []T, offsetMSD T, mask int, bits uint, tables *digits_tables) {
+	if bits == 0 {
+		bits = digit_bits_lsd(len(xs), 32)
+	}
+	if bits > 8 {
+		digits_lsd(xs, ys, uint64(offsetMSD)<<24, 0, 32, bits, tables)
+		return
+	}
+	if counter_wide(len(xs)) {
+		int32_least_significant_digit[T, uint64](xs, ys, offsetMSD, mask)
+		return
+	}
+	int32_least_significant_digit[T, uint32](xs, ys, offsetMSD, mask)
+}
+
+// int32_flag sorts xs with int32_american_flag, using the counter width suited
+// to the length of xs.
+func int32_flag[T word32](xs []T, offset T, cutoff int) {
+	if counter_wide(len(xs)) {
+		int32_american_flag(xs, offset, 24, uint64(cutoff))
+		return
+	}
+	int32_american_flag(xs, offset, 24, uint32(cutoff))
+}
+
+// int32_most_significant_digit sorts xs on the digit at shift and recurses into
+// the buckets, which are sorted by insertion sort once they have cutoff elements
+// or less. Buckets are laid out in increasing order of digits if mask is 0, and
+// in decreasing order if mask is 0xFF.
+func int32_most_significant_digit[T word32, C counter](xs, temp []T, is *[256]C, offset T, shift uint, mask int, cutoff C) {
+	var cs [256]C
+	word_histogram(xs, &cs, is, offset, shift, mask)
 	for _, x := range xs {
 		r := (offset + (x >> shift)) & 0xFF
 		temp[is[r]] = x
@@ -76,7 +246,56 @@ func int32_most_significant_digit(xs, temp []int32, is *[256]uint32, offset int3
 		return
 	}
 
-	var lo uint32
+	var lo C
+	for i := 0; i < 256; i++ {
+		var (
+			c  = cs[i^mask]
+			hi = lo + c
+			zs = xs[lo:hi]
+		)
+		lo = hi
+
+		switch {
+		case c < 2: // already sorted
+		case c <= cutoff && mask == 0:
+			integer_insertion(zs) // ~linear runtime when globally sorted, locally not-sorted
+		case c <= cutoff:
+			integer_insertion_desc(zs)
+		default:
+			int32_most_significant_digit(zs, temp, is, 0, shift-8, mask, cutoff)
+		}
+	}
+}
+
+// int32_american_flag sorts xs in place on the digit at shift, by following
+// cycles of misplaced elements until every bucket is filled, and recurses into
+// the buckets like int32_most_significant_digit.
+func int32_american_flag[T word32, C counter](xs []T, offset T, shift uint, cutoff C) {
+	var cs, is [256]C
+	word_histogram(xs, &cs, &is, offset, shift, 0)
+
+	hi := C(0)
+	for i := T(0); i < 256; i++ {
+		hi += cs[i]
+		for is[i] < hi { // is[i] is the next unsorted element in bucket i
+			x := xs[is[i]]
+			r := (offset + (x >> shift)) & 0xFF
+			for r != i { // swap x to its bucket and pick up the element it replaces
+				j := is[r]
+				is[r]++
+				xs[j], x = x, xs[j]
+				r = (offset + (x >> shift)) & 0xFF
+			}
+			xs[is[i]] = x
+			is[i]++
+		}
+	}
+
+	if shift == 0 { // that was the last radix digit
+		return
+	}
+
+	var lo C
 	for i := 0; i < 256; i++ {
 		var (
 			c  = cs[i]
@@ -87,16 +306,20 @@ func int32_most_significant_digit(xs, temp []int32, is *[256]uint32, offset int3
 
 		switch {
 		case c < 2: // already sorted
-		case c <= 100:
-			int32_insertion(zs) // ~linear runtime when globally sorted, locally not-sorted
+		case c <= cutoff:
+			integer_insertion(zs)
 		default:
-			int32_most_significant_digit(zs, temp, is, 0, shift-8)
+			int32_american_flag(zs, 0, shift-8, cutoff)
 		}
 	}
 }
 
-func int32_least_significant_digit(xs []int32, offsetMSD int32) {
-	var css [4][256]uint32 // should be living on the stack
+// int32_least_significant_digit sorts xs using ys as swap space. ys must have
+// the same length as xs. xs is sorted in increasing order if mask is 0, and in
+// decreasing order if mask is 0xFF. Digits shared by all elements are not
+// scattered.
+func int32_least_significant_digit[T word32, C counter](xs, ys []T, offsetMSD T, mask int) {
+	var css [4][256]C // should be living on the stack
 
 	// count all radix keys
 	for _, x := range xs {
@@ -112,23 +335,29 @@ func int32_least_significant_digit(xs []int32, offsetMSD int32) {
 		css[3][d]++
 	}
 
-	// aggregate radix counts to radix offsets
+	// aggregate radix counts to radix offsets, in decreasing order of digits
+	// when mask is set, and find digits shared by all elements
+	var constant [4]bool
 	for i := range css {
 		cs := &css[i]
-		a := uint32(0)
+		a := C(0)
 		for j := 0; j < 256; j++ {
-			c := cs[j]
-			cs[j] = a
+			c := cs[j^mask]
+			cs[j^mask] = a
 			a += c
+			constant[i] = constant[i] || c == C(len(xs))
 		}
 	}
 
 	var (
-		ys = make([]int32, len(xs)) // temp array for swapping elements
 		ss = [4]uint{0, 8, 16, 24}
-		os = [4]int32{0, 0, 0, offsetMSD}
+		os = [4]T{0, 0, 0, offsetMSD}
 	)
+	swaps := 0
 	for i := range css {
+		if constant[i] { // scattering would leave xs unchanged
+			continue
+		}
 		var (
 			cs     = css[i] // do not obtain cs from range expr
 			shift  = ss[i]
@@ -140,28 +369,104 @@ func int32_least_significant_digit(xs []int32, offsetMSD int32) {
 			cs[r]++
 			ys[j] = x
 		}
-		xs, ys = ys, xs // even number of swap
+		xs, ys = ys, xs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the swap space
+		copy(ys, xs)
 	}
 }
 
-func int32_insertion(xs []int32) {
-	for i := 1; i < len(xs); i++ {
-		j, x := i, xs[i]
-		for j > 0 && xs[j-1] > x {
-			xs[j] = xs[j-1]
-			j--
+// buffer_check panics if a swap space of length m cannot be used to sort n
+// elements.
+func buffer_check(n, m int) {
+	if m < n {
+		panic("radixsort: buffer is shorter than the input slice")
+	}
+}
+
+// uint32_pairs sorts ks in unsigned order and moves every vs[i] in lockstep
+// with ks[i], keeping the input order of equal keys.
+func uint32_pairs[V any](ks []uint32, vs []V) {
+	if len(ks) <= 64 {
+		uint32_insertion_pairs(ks, vs)
+		return
+	}
+	if counter_wide(len(ks)) {
+		uint32_least_significant_digit_pairs[V, uint64](ks, vs)
+		return
+	}
+	uint32_least_significant_digit_pairs[V, uint32](ks, vs)
+}
+
+// uint32_least_significant_digit_pairs sorts ks in unsigned order and moves
+// every vs[i] in lockstep with ks[i]. Scatter passes preserve the input order
+// of equal keys, hence the sort is stable. Digits shared by all keys are not
+// scattered.
+func uint32_least_significant_digit_pairs[V any, C counter](ks []uint32, vs []V) {
+	var css [4][256]C // should be living on the stack
+
+	// count all radix keys
+	for _, k := range ks {
+		css[0][k&0xFF]++
+		css[1][(k>>8)&0xFF]++
+		css[2][(k>>16)&0xFF]++
+		css[3][k>>24]++
+	}
+
+	// aggregate radix counts to radix offsets, and find digits shared by all
+	// keys
+	var constant [4]bool
+	for i := range css {
+		cs := &css[i]
+		a := C(0)
+		for j := 0; j < 256; j++ {
+			c := cs[j]
+			cs[j] = a
+			a += c
+			constant[i] = constant[i] || c == C(len(ks))
+		}
+	}
+
+	var (
+		ls = make([]uint32, len(ks)) // temp arrays for swapping elements
+		ws = make([]V, len(vs))
+	)
+	swaps := 0
+	for i := range css {
+		if constant[i] { // scattering would leave ks and vs unchanged
+			continue
 		}
-		xs[j] = x
+		var (
+			cs    = css[i] // do not obtain cs from range expr
+			shift = uint(8 * i)
+		)
+		for n, k := range ks {
+			r := (k >> shift) & 0xFF
+			j := cs[r]
+			cs[r]++
+			ls[j] = k
+			ws[j] = vs[n]
+		}
+		ks, ls = ls, ks
+		vs, ws = ws, vs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the temp arrays
+		copy(ls, ks)
+		copy(ws, vs)
 	}
 }
 
-func uint32_insertion(xs []uint32) {
-	for i := 1; i < len(xs); i++ {
-		j, x := i, xs[i]
-		for j > 0 && xs[j-1] > x {
-			xs[j] = xs[j-1]
+func uint32_insertion_pairs[V any](ks []uint32, vs []V) {
+	for i := 1; i < len(ks); i++ {
+		j, k, v := i, ks[i], vs[i]
+		for j > 0 && ks[j-1] > k {
+			ks[j] = ks[j-1]
+			vs[j] = vs[j-1]
 			j--
 		}
-		xs[j] = x
+		ks[j] = k
+		vs[j] = v
 	}
 }
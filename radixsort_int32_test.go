@@ -1,7 +1,9 @@
 package radixsort
 
 import (
+	"slices"
 	"sort"
+	"strings"
 	"testing"
 )
 
@@ -9,9 +11,16 @@ func TestInt32Sorting(t *testing.T) {
 	var (
 		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
 		sorter = map[string]func([]int32){
-			"int32 radix sort MSD": Int32MSD,
-			"int32 radix sort LSD": Int32LSD,
-			"int32 standard sort":  int32_stdSort,
+			"int32 radix sort MSD":      Int32MSD,
+			"int32 radix sort LSD":      Int32LSD,
+			"int32 radix sort in place": Int32InPlace,
+			"int32 standard sort":       int32_stdSort,
+			"int32 radix sort MSD with buffer": func(xs []int32) {
+				Int32MSDWithBuffer(xs, make([]int32, len(xs)+7))
+			},
+			"int32 radix sort LSD with buffer": func(xs []int32) {
+				Int32LSDWithBuffer(xs, make([]int32, len(xs)+7))
+			},
 		}
 	)
 	for _, size := range sizes {
@@ -27,6 +36,69 @@ func TestInt32Sorting(t *testing.T) {
 	}
 }
 
+func TestInt32DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]int32){
+			"int32 radix sort desc":     Int32Desc,
+			"int32 radix sort MSD desc": Int32MSDDesc,
+			"int32 radix sort LSD desc": Int32LSDDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := int32_pop(size)
+		for n := 0; n < size; n += 2 { // add duplicates
+			xs[n] &= 0xFFFF
+		}
+		for desc, s := range sorter {
+			ys := make([]int32, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.IsSorted(sort.Reverse(byInt32(ys))) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func TestInt32ConstantDigits(t *testing.T) {
+	var (
+		masks  = []int32{0, 0xFF, 0xFFFF, 0xFF00FF, 0xFFFFFF}
+		sorter = map[string]func([]int32){
+			"int32 radix sort LSD":      Int32LSD,
+			"int32 radix sort LSD desc": Int32LSDDesc,
+			"int32 radix sort LSD with 11bits digits": func(xs []int32) {
+				(&Sorter{Algorithm: LSD, DigitBits: 11}).Int32(xs)
+			},
+			"int32 radix sort LSD with 16bits digits": func(xs []int32) {
+				(&Sorter{Algorithm: LSD, DigitBits: 16}).Int32(xs)
+			},
+		}
+	)
+	for _, mask := range masks {
+		for _, base := range []int32{1.6e9, -1} { // timestamps, negative ints
+			xs := int32_pop(1e4)
+			for i := range xs {
+				xs[i] = base - xs[i]&mask
+			}
+			want := make([]int32, len(xs))
+			copy(want, xs)
+			int32_stdSort(want)
+			for desc, s := range sorter {
+				ys := make([]int32, len(xs))
+				copy(ys, xs)
+				s(ys)
+				if strings.HasSuffix(desc, "desc") {
+					slices.Reverse(ys)
+				}
+				if !slices.Equal(ys, want) {
+					t.Errorf("keys %x - x&%x were not correctly sorted by %s", base, mask, desc)
+				}
+			}
+		}
+	}
+}
+
 func Benchmark_Int32_RadixMSD_100(b *testing.B)    { benchmarkInt32(b, Int32MSD, 100) }
 func Benchmark_Int32_RadixMSD_1000(b *testing.B)   { benchmarkInt32(b, Int32MSD, 1000) }
 func Benchmark_Int32_RadixMSD_10000(b *testing.B)  { benchmarkInt32(b, Int32MSD, 10000) }
@@ -37,12 +109,29 @@ func Benchmark_Int32_RadixLSD_1000(b *testing.B)   { benchmarkInt32(b, Int32LSD,
 func Benchmark_Int32_RadixLSD_10000(b *testing.B)  { benchmarkInt32(b, Int32LSD, 10000) }
 func Benchmark_Int32_RadixLSD_100000(b *testing.B) { benchmarkInt32(b, Int32LSD, 100000) }
 
+func Benchmark_Int32_RadixInPlace_100(b *testing.B)    { benchmarkInt32(b, Int32InPlace, 100) }
+func Benchmark_Int32_RadixInPlace_1000(b *testing.B)   { benchmarkInt32(b, Int32InPlace, 1000) }
+func Benchmark_Int32_RadixInPlace_10000(b *testing.B)  { benchmarkInt32(b, Int32InPlace, 10000) }
+func Benchmark_Int32_RadixInPlace_100000(b *testing.B) { benchmarkInt32(b, Int32InPlace, 100000) }
+
+func Benchmark_Int32_RadixMSDWithBuffer_100000(b *testing.B) {
+	buf := make([]int32, 100000)
+	benchmarkInt32(b, func(xs []int32) { Int32MSDWithBuffer(xs, buf) }, 100000)
+}
+func Benchmark_Int32_RadixLSDWithBuffer_100000(b *testing.B) {
+	buf := make([]int32, 100000)
+	benchmarkInt32(b, func(xs []int32) { Int32LSDWithBuffer(xs, buf) }, 100000)
+}
+
+func Benchmark_Int32_RadixMSDDesc_100000(b *testing.B) { benchmarkInt32(b, Int32MSDDesc, 100000) }
+func Benchmark_Int32_RadixLSDDesc_100000(b *testing.B) { benchmarkInt32(b, Int32LSDDesc, 100000) }
+
 func Benchmark_Int32_StandardSort_100(b *testing.B)    { benchmarkInt32(b, int32_stdSort, 100) }
 func Benchmark_Int32_StandardSort_1000(b *testing.B)   { benchmarkInt32(b, int32_stdSort, 1000) }
 func Benchmark_Int32_StandardSort_10000(b *testing.B)  { benchmarkInt32(b, int32_stdSort, 10000) }
 func Benchmark_Int32_StandardSort_100000(b *testing.B) { benchmarkInt32(b, int32_stdSort, 100000) }
 
-func Benchmark_Int32_Insertion_100(b *testing.B) { benchmarkInt32(b, int32_insertion, 100) }
+func Benchmark_Int32_Insertion_100(b *testing.B) { benchmarkInt32(b, integer_insertion[int32], 100) }
 
 func Benchmark_Int32OneDigit_RadixMSD_10000(b *testing.B) {
 	benchmarkInt32OneDigit(b, Int32MSD, 10000)
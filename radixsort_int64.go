@@ -1,70 +1,240 @@
 package radixsort
 
-import (
-	"unsafe"
-)
-
 // Radix sort for int64. Int64 delegates to most significant digit radix sort.
-func Int64(xs []int64) { Int64MSD(xs) }
+// When the values of xs lie in a narrow range, only the low bits of their
+// difference with the smallest value are sorted.
+func Int64(xs []int64) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	temp := make([]int64, len(xs))
+	if !range_sort(xs, temp, 1<<63, 64, 100, 0, nil) {
+		int64_msd(xs, temp, 1<<7, 0, 100, 0, nil)
+	}
+}
 
 // Radix sort for uint64. Uint64 delegates to most significant digit radix sort.
-func Uint64(xs []uint64) { Uint64MSD(xs) }
+// When the values of xs lie in a narrow range, only the low bits of their
+// difference with the smallest value are sorted.
+func Uint64(xs []uint64) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	temp := make([]uint64, len(xs))
+	if !range_sort(xs, temp, 0, 64, 100, 0, nil) {
+		int64_msd(xs, temp, 0, 0, 100, 0, nil)
+	}
+}
 
-// Most significant digit radix sort for int64.
+// Most significant digit radix sort for int64. Unlike Int64, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Int64MSD(xs []int64) {
 	if len(xs) <= 64 {
-		int64_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	var (
-		temp = make([]int64, len(xs))
-		is   [256]uint32
-	)
-	int64_most_significant_digit(xs, temp, &is, 1<<7, 56)
+	int64_msd(xs, make([]int64, len(xs)), 1<<7, 0, 100, 0, nil)
 }
 
-// Most significant digit radix sort for uint64.
+// Most significant digit radix sort for uint64. Unlike Uint64, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Uint64MSD(xs []uint64) {
 	if len(xs) <= 64 {
-		uint64_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	var (
-		temp = make([]int64, len(xs))
-		is   [256]uint32
-	)
-	int64_most_significant_digit(*(*[]int64)(unsafe.Pointer(&xs)), temp, &is, 0, 56)
+	int64_msd(xs, make([]uint64, len(xs)), 0, 0, 100, 0, nil)
 }
 
-// Least significant digit radix sort for int64.
+// Least significant digit radix sort for int64. Unlike Int64, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Int64LSD(xs []int64) {
 	if len(xs) <= 64 {
-		int64_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	int64_least_significant_digit(xs, 1<<7)
+	int64_lsd(xs, make([]int64, len(xs)), 1<<7, 0, 0, nil)
 }
 
-// Least significant digit radix sort for uint64.
+// Least significant digit radix sort for uint64. Unlike Uint64, it does not
+// scan xs for the range of its values, and sorts all of their digits.
 func Uint64LSD(xs []uint64) {
 	if len(xs) <= 64 {
-		uint64_insertion(xs)
+		integer_insertion(xs)
 		return
 	}
-	int64_least_significant_digit(*(*[]int64)(unsafe.Pointer(&xs)), 0)
+	int64_lsd(xs, make([]uint64, len(xs)), 0, 0, 0, nil)
 }
 
-func int64_most_significant_digit(xs, temp []int64, is *[256]uint32, offset int64, shift uint) {
-	var cs [256]uint32
-	for _, x := range xs {
-		r := (offset + (x >> shift)) & 0xFF
-		cs[r]++
+// In place most significant digit radix sort for int64, also known as
+// American flag sort. It uses no swap space, but is slower than Int64MSD.
+func Int64InPlace(xs []int64) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
 	}
-	a := uint32(0)
-	for i := 0; i < 256; i++ {
-		is[i] = a
-		a += cs[i]
+	int64_flag(xs, 1<<7, 100)
+}
+
+// In place most significant digit radix sort for uint64, also known as
+// American flag sort. It uses no swap space, but is slower than Uint64MSD.
+func Uint64InPlace(xs []uint64) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int64_flag(xs, 0, 100)
+}
+
+// Most significant digit radix sort for int64, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Int64MSDWithBuffer(xs, buf []int64) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int64_msd(xs, buf[:len(xs)], 1<<7, 0, 100, 0, nil)
+}
+
+// Most significant digit radix sort for uint64, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Uint64MSDWithBuffer(xs, buf []uint64) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int64_msd(xs, buf[:len(xs)], 0, 0, 100, 0, nil)
+}
+
+// Least significant digit radix sort for int64, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Int64LSDWithBuffer(xs, buf []int64) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int64_lsd(xs, buf[:len(xs)], 1<<7, 0, 0, nil)
+}
+
+// Least significant digit radix sort for uint64, using buf as swap space instead
+// of allocating it. Panics if buf is shorter than xs.
+func Uint64LSDWithBuffer(xs, buf []uint64) {
+	buffer_check(len(xs), len(buf))
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	int64_lsd(xs, buf[:len(xs)], 0, 0, 0, nil)
+}
+
+// Radix sort for int64 in decreasing order. Int64Desc delegates to
+// most significant digit radix sort.
+func Int64Desc(xs []int64) { Int64MSDDesc(xs) }
+
+// Radix sort for uint64 in decreasing order. Uint64Desc delegates to
+// most significant digit radix sort.
+func Uint64Desc(xs []uint64) { Uint64MSDDesc(xs) }
+
+// Most significant digit radix sort for int64 in decreasing order.
+func Int64MSDDesc(xs []int64) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int64_msd(xs, make([]int64, len(xs)), 1<<7, 0xFF, 100, 8, nil)
+}
+
+// Most significant digit radix sort for uint64 in decreasing order.
+func Uint64MSDDesc(xs []uint64) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int64_msd(xs, make([]uint64, len(xs)), 0, 0xFF, 100, 8, nil)
+}
+
+// Least significant digit radix sort for int64 in decreasing order.
+func Int64LSDDesc(xs []int64) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int64_lsd(xs, make([]int64, len(xs)), 1<<7, 0xFF, 8, nil)
+}
+
+// Least significant digit radix sort for uint64 in decreasing order.
+func Uint64LSDDesc(xs []uint64) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	int64_lsd(xs, make([]uint64, len(xs)), 0, 0xFF, 8, nil)
+}
+
+// int64_msd sorts xs with int64_most_significant_digit, using temp as swap
+// space and the counter width suited to the length of xs. Digits are bits wide,
+// or selected from the length of xs if bits is 0. Digits wider than 8 bits are
+// sorted by digits_msd with the count tables of tables, in increasing order
+// only.
+func int64_msd[T word64](xs, temp []T, offset T, mask int, cutoff int, bits uint, tables *digits_tables) {
+	if bits == 0 {
+		bits = digit_bits_msd(len(xs))
+	}
+	if bits > 8 {
+		digits_msd(xs, temp, uint64(offset)<<56, 0, 64, bits, cutoff, tables)
+		return
 	}
+	if counter_wide(len(xs)) {
+		var is [256]uint64
+		int64_most_significant_digit(xs, temp, &is, offset, 56, mask, uint64(cutoff))
+		return
+	}
+	var is [256]uint32
+	int64_most_significant_digit(xs, temp, &is, offset, 56, mask, uint32(cutoff))
+}
+
+// int64_lsd sorts xs with int64_least_significant_digit, using ys as swap
+// space and the counter width suited to the length of xs. Digits are bits wide,
+// or selected from the length of xs if bits is 0. Digits wider than 8 bits are
+// sorted by digits_lsd with the count tables of tables, in increasing order
+// only.
+func int64_lsd[T word64](xs, ys []T, offsetMSD T, mask int, bits uint, tables *digits_tables) {
+	if bits == 0 {
+		bits = digit_bits_lsd(len(xs), 64)
+	}
+	if bits > 8 {
+		digits_lsd(xs, ys, uint64(offsetMSD)<<56, 0, 64, bits, tables)
+		return
+	}
+	if counter_wide(len(xs)) {
+		int64_least_significant_digit[T, uint64](xs, ys, offsetMSD, mask)
+		return
+	}
+	int64_least_significant_digit[T, uint32](xs, ys, offsetMSD, mask)
+}
+
+// int64_flag sorts xs with int64_american_flag, using the counter width suited
+// to the length of xs.
+func int64_flag[T word64](xs []T, offset T, cutoff int) {
+	if counter_wide(len(xs)) {
+		int64_american_flag(xs, offset, 56, uint64(cutoff))
+		return
+	}
+	int64_american_flag(xs, offset, 56, uint32(cutoff))
+}
+
+// int64_most_significant_digit sorts xs on the digit at shift and recurses into
+// the buckets, which are sorted by insertion sort once they have cutoff elements
+// or less. Buckets are laid out in increasing order of digits if mask is 0, and
+// in decreasing order if mask is 0xFF.
+func int64_most_significant_digit[T word64, C counter](xs, temp []T, is *[256]C, offset T, shift uint, mask int, cutoff C) {
+	var cs [256]C
+	word_histogram(xs, &cs, is, offset, shift, mask)
 	for _, x := range xs {
 		r := (offset + (x >> shift)) & 0xFF
 		temp[is[r]] = x
@@ -76,7 +246,56 @@ func int64_most_significant_digit(xs, temp []int64, is *[256]uint32, offset int6
 		return
 	}
 
-	var lo uint32
+	var lo C
+	for i := 0; i < 256; i++ {
+		var (
+			c  = cs[i^mask]
+			hi = lo + c
+			zs = xs[lo:hi]
+		)
+		lo = hi
+
+		switch {
+		case c < 2: // already sorted
+		case c <= cutoff && mask == 0:
+			integer_insertion(zs) // ~linear runtime when globally sorted, locally not-sorted
+		case c <= cutoff:
+			integer_insertion_desc(zs)
+		default:
+			int64_most_significant_digit(zs, temp, is, 0, shift-8, mask, cutoff)
+		}
+	}
+}
+
+// int64_american_flag sorts xs in place on the digit at shift, by following
+// cycles of misplaced elements until every bucket is filled, and recurses into
+// the buckets like int64_most_significant_digit.
+func int64_american_flag[T word64, C counter](xs []T, offset T, shift uint, cutoff C) {
+	var cs, is [256]C
+	word_histogram(xs, &cs, &is, offset, shift, 0)
+
+	hi := C(0)
+	for i := T(0); i < 256; i++ {
+		hi += cs[i]
+		for is[i] < hi { // is[i] is the next unsorted element in bucket i
+			x := xs[is[i]]
+			r := (offset + (x >> shift)) & 0xFF
+			for r != i { // swap x to its bucket and pick up the element it replaces
+				j := is[r]
+				is[r]++
+				xs[j], x = x, xs[j]
+				r = (offset + (x >> shift)) & 0xFF
+			}
+			xs[is[i]] = x
+			is[i]++
+		}
+	}
+
+	if shift == 0 { // that was the last radix digit
+		return
+	}
+
+	var lo C
 	for i := 0; i < 256; i++ {
 		var (
 			c  = cs[i]
@@ -87,16 +306,20 @@ func int64_most_significant_digit(xs, temp []int64, is *[256]uint32, offset int6
 
 		switch {
 		case c < 2: // already sorted
-		case c <= 100:
-			int64_insertion(zs) // ~linear runtime when globally sorted, locally not-sorted
+		case c <= cutoff:
+			integer_insertion(zs)
 		default:
-			int64_most_significant_digit(zs, temp, is, 0, shift-8)
+			int64_american_flag(zs, 0, shift-8, cutoff)
 		}
 	}
 }
 
-func int64_least_significant_digit(xs []int64, offsetMSD int64) {
-	var css [8][256]uint32 // should be living on the stack
+// int64_least_significant_digit sorts xs using ys as swap space. ys must have
+// the same length as xs. xs is sorted in increasing order if mask is 0, and in
+// decreasing order if mask is 0xFF. Digits shared by all elements are not
+// scattered.
+func int64_least_significant_digit[T word64, C counter](xs, ys []T, offsetMSD T, mask int) {
+	var css [8][256]C // should be living on the stack
 
 	// count all radix keys
 	for _, x := range xs {
@@ -120,23 +343,29 @@ func int64_least_significant_digit(xs []int64, offsetMSD int64) {
 		css[7][h]++
 	}
 
-	// aggregate radix counts to radix offsets
+	// aggregate radix counts to radix offsets, in decreasing order of digits
+	// when mask is set, and find digits shared by all elements
+	var constant [8]bool
 	for i := range css {
 		cs := &css[i]
-		a := uint32(0)
+		a := C(0)
 		for j := 0; j < 256; j++ {
-			c := cs[j]
-			cs[j] = a
+			c := cs[j^mask]
+			cs[j^mask] = a
 			a += c
+			constant[i] = constant[i] || c == C(len(xs))
 		}
 	}
 
 	var (
-		ys = make([]int64, len(xs)) // temp array for swapping elements
 		ss = [8]uint{0, 8, 16, 24, 32, 40, 48, 56}
-		os = [8]int64{0, 0, 0, 0, 0, 0, 0, offsetMSD}
+		os = [8]T{0, 0, 0, 0, 0, 0, 0, offsetMSD}
 	)
+	swaps := 0
 	for i := range css {
+		if constant[i] { // scattering would leave xs unchanged
+			continue
+		}
 		var (
 			cs     = css[i] // do not obtain cs from range expr
 			shift  = ss[i]
@@ -148,28 +377,100 @@ func int64_least_significant_digit(xs []int64, offsetMSD int64) {
 			cs[r]++
 			ys[j] = x
 		}
-		xs, ys = ys, xs // even number of swap
+		xs, ys = ys, xs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the swap space
+		copy(ys, xs)
 	}
 }
 
-func int64_insertion(xs []int64) {
-	for i := 1; i < len(xs); i++ {
-		j, x := i, xs[i]
-		for j > 0 && xs[j-1] > x {
-			xs[j] = xs[j-1]
-			j--
+// uint64_pairs sorts ks in unsigned order and moves every vs[i] in lockstep
+// with ks[i], keeping the input order of equal keys.
+func uint64_pairs[V any](ks []uint64, vs []V) {
+	if len(ks) <= 64 {
+		uint64_insertion_pairs(ks, vs)
+		return
+	}
+	if counter_wide(len(ks)) {
+		uint64_least_significant_digit_pairs[V, uint64](ks, vs)
+		return
+	}
+	uint64_least_significant_digit_pairs[V, uint32](ks, vs)
+}
+
+// uint64_least_significant_digit_pairs sorts ks in unsigned order and moves
+// every vs[i] in lockstep with ks[i]. Scatter passes preserve the input order
+// of equal keys, hence the sort is stable. Digits shared by all keys are not
+// scattered.
+func uint64_least_significant_digit_pairs[V any, C counter](ks []uint64, vs []V) {
+	var css [8][256]C // should be living on the stack
+
+	// count all radix keys
+	for _, k := range ks {
+		css[0][k&0xFF]++
+		css[1][(k>>8)&0xFF]++
+		css[2][(k>>16)&0xFF]++
+		css[3][(k>>24)&0xFF]++
+		css[4][(k>>32)&0xFF]++
+		css[5][(k>>40)&0xFF]++
+		css[6][(k>>48)&0xFF]++
+		css[7][k>>56]++
+	}
+
+	// aggregate radix counts to radix offsets, and find digits shared by all
+	// keys
+	var constant [8]bool
+	for i := range css {
+		cs := &css[i]
+		a := C(0)
+		for j := 0; j < 256; j++ {
+			c := cs[j]
+			cs[j] = a
+			a += c
+			constant[i] = constant[i] || c == C(len(ks))
 		}
-		xs[j] = x
+	}
+
+	var (
+		ls = make([]uint64, len(ks)) // temp arrays for swapping elements
+		ws = make([]V, len(vs))
+	)
+	swaps := 0
+	for i := range css {
+		if constant[i] { // scattering would leave ks and vs unchanged
+			continue
+		}
+		var (
+			cs    = css[i] // do not obtain cs from range expr
+			shift = uint(8 * i)
+		)
+		for n, k := range ks {
+			r := (k >> shift) & 0xFF
+			j := cs[r]
+			cs[r]++
+			ls[j] = k
+			ws[j] = vs[n]
+		}
+		ks, ls = ls, ks
+		vs, ws = ws, vs
+		swaps++
+	}
+	if swaps%2 == 1 { // sorted elements are in the temp arrays
+		copy(ls, ks)
+		copy(ws, vs)
 	}
 }
 
-func uint64_insertion(xs []uint64) {
-	for i := 1; i < len(xs); i++ {
-		j, x := i, xs[i]
-		for j > 0 && xs[j-1] > x {
-			xs[j] = xs[j-1]
+func uint64_insertion_pairs[V any](ks []uint64, vs []V) {
+	for i := 1; i < len(ks); i++ {
+		j, k, v := i, ks[i], vs[i]
+		for j > 0 && ks[j-1] > k {
+			ks[j] = ks[j-1]
+			vs[j] = vs[j-1]
 			j--
 		}
-		xs[j] = x
+		ks[j] = k
+		vs[j] = v
 	}
 }
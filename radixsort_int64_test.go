@@ -1,7 +1,9 @@
 package radixsort
 
 import (
+	"slices"
 	"sort"
+	"strings"
 	"testing"
 )
 
@@ -9,9 +11,16 @@ func TestInt64Sorting(t *testing.T) {
 	var (
 		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
 		sorter = map[string]func([]int64){
-			"int64 radix sort MSD": Int64MSD,
-			"int64 radix sort LSD": Int64LSD,
-			"int64 standard sort":  int64_stdSort,
+			"int64 radix sort MSD":      Int64MSD,
+			"int64 radix sort LSD":      Int64LSD,
+			"int64 radix sort in place": Int64InPlace,
+			"int64 standard sort":       int64_stdSort,
+			"int64 radix sort MSD with buffer": func(xs []int64) {
+				Int64MSDWithBuffer(xs, make([]int64, len(xs)+7))
+			},
+			"int64 radix sort LSD with buffer": func(xs []int64) {
+				Int64LSDWithBuffer(xs, make([]int64, len(xs)+7))
+			},
 		}
 	)
 	for _, size := range sizes {
@@ -27,6 +36,86 @@ func TestInt64Sorting(t *testing.T) {
 	}
 }
 
+func TestInt64ShortBuffer(t *testing.T) {
+	sorter := map[string]func([]int64, []int64){
+		"int64 radix sort MSD with buffer": Int64MSDWithBuffer,
+		"int64 radix sort LSD with buffer": Int64LSDWithBuffer,
+	}
+	for desc, s := range sorter {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic on a short buffer", desc)
+				}
+			}()
+			s(make([]int64, 10), make([]int64, 9))
+		}()
+	}
+}
+
+func TestInt64DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]int64){
+			"int64 radix sort desc":     Int64Desc,
+			"int64 radix sort MSD desc": Int64MSDDesc,
+			"int64 radix sort LSD desc": Int64LSDDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := int64_pop(size)
+		for n := 0; n < size; n += 2 { // add duplicates
+			xs[n] &= 0xFFFF
+		}
+		for desc, s := range sorter {
+			ys := make([]int64, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.IsSorted(sort.Reverse(byInt64(ys))) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+func TestInt64ConstantDigits(t *testing.T) {
+	var (
+		masks  = []int64{0, 0xFF, 0xFFFF, 0xFF00FF, 0xFFFFFF, 0xFFFFFFFFFF}
+		sorter = map[string]func([]int64){
+			"int64 radix sort LSD":      Int64LSD,
+			"int64 radix sort LSD desc": Int64LSDDesc,
+			"int64 radix sort LSD with 11bits digits": func(xs []int64) {
+				(&Sorter{Algorithm: LSD, DigitBits: 11}).Int64(xs)
+			},
+			"int64 radix sort LSD with 16bits digits": func(xs []int64) {
+				(&Sorter{Algorithm: LSD, DigitBits: 16}).Int64(xs)
+			},
+		}
+	)
+	for _, mask := range masks {
+		for _, base := range []int64{1.6e18, -1} { // timestamps, negative ints
+			xs := int64_pop(1e4)
+			for i := range xs {
+				xs[i] = base - xs[i]&mask
+			}
+			want := make([]int64, len(xs))
+			copy(want, xs)
+			int64_stdSort(want)
+			for desc, s := range sorter {
+				ys := make([]int64, len(xs))
+				copy(ys, xs)
+				s(ys)
+				if strings.HasSuffix(desc, "desc") {
+					slices.Reverse(ys)
+				}
+				if !slices.Equal(ys, want) {
+					t.Errorf("keys %x - x&%x were not correctly sorted by %s", base, mask, desc)
+				}
+			}
+		}
+	}
+}
+
 func Benchmark_Int64_RadixMSD_100(b *testing.B)    { benchmarkInt64(b, Int64MSD, 100) }
 func Benchmark_Int64_RadixMSD_1000(b *testing.B)   { benchmarkInt64(b, Int64MSD, 1000) }
 func Benchmark_Int64_RadixMSD_10000(b *testing.B)  { benchmarkInt64(b, Int64MSD, 10000) }
@@ -37,12 +126,43 @@ func Benchmark_Int64_RadixLSD_1000(b *testing.B)   { benchmarkInt64(b, Int64LSD,
 func Benchmark_Int64_RadixLSD_10000(b *testing.B)  { benchmarkInt64(b, Int64LSD, 10000) }
 func Benchmark_Int64_RadixLSD_100000(b *testing.B) { benchmarkInt64(b, Int64LSD, 100000) }
 
+func Benchmark_Int64_RadixLSDTimestamps_100000(b *testing.B) {
+	ys := make([][]int64, b.N)
+	for n := range ys {
+		ys[n] = int64_pop(100000)
+		for i, y := range ys[n] { // seconds since epoch share their 4 high bytes
+			ys[n][i] = 1.6e9 + y&0xFFFFFF
+		}
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Int64LSD(ys[n])
+	}
+}
+
+func Benchmark_Int64_RadixInPlace_100(b *testing.B)    { benchmarkInt64(b, Int64InPlace, 100) }
+func Benchmark_Int64_RadixInPlace_1000(b *testing.B)   { benchmarkInt64(b, Int64InPlace, 1000) }
+func Benchmark_Int64_RadixInPlace_10000(b *testing.B)  { benchmarkInt64(b, Int64InPlace, 10000) }
+func Benchmark_Int64_RadixInPlace_100000(b *testing.B) { benchmarkInt64(b, Int64InPlace, 100000) }
+
+func Benchmark_Int64_RadixMSDWithBuffer_100000(b *testing.B) {
+	buf := make([]int64, 100000)
+	benchmarkInt64(b, func(xs []int64) { Int64MSDWithBuffer(xs, buf) }, 100000)
+}
+func Benchmark_Int64_RadixLSDWithBuffer_100000(b *testing.B) {
+	buf := make([]int64, 100000)
+	benchmarkInt64(b, func(xs []int64) { Int64LSDWithBuffer(xs, buf) }, 100000)
+}
+
+func Benchmark_Int64_RadixMSDDesc_100000(b *testing.B) { benchmarkInt64(b, Int64MSDDesc, 100000) }
+func Benchmark_Int64_RadixLSDDesc_100000(b *testing.B) { benchmarkInt64(b, Int64LSDDesc, 100000) }
+
 func Benchmark_Int64_StandardSort_100(b *testing.B)    { benchmarkInt64(b, int64_stdSort, 100) }
 func Benchmark_Int64_StandardSort_1000(b *testing.B)   { benchmarkInt64(b, int64_stdSort, 1000) }
 func Benchmark_Int64_StandardSort_10000(b *testing.B)  { benchmarkInt64(b, int64_stdSort, 10000) }
 func Benchmark_Int64_StandardSort_100000(b *testing.B) { benchmarkInt64(b, int64_stdSort, 100000) }
 
-func Benchmark_Int64_Insertion_100(b *testing.B) { benchmarkInt64(b, int64_insertion, 100) }
+func Benchmark_Int64_Insertion_100(b *testing.B) { benchmarkInt64(b, integer_insertion[int64], 100) }
 
 func benchmarkInt64(b *testing.B, sorter func([]int64), size int) {
 	ys := make([][]int64, b.N)
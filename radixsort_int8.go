@@ -0,0 +1,88 @@
+package radixsort
+
+// Counting sort for int8. The values of xs are counted, then xs is rewritten
+// from the counts, without swap space.
+func Int8(xs []int8) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint8_counting(xs, 1<<7, 0)
+}
+
+// Counting sort for uint8. The values of xs are counted, then xs is rewritten
+// from the counts, without swap space.
+func Uint8(xs []uint8) {
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	uint8_counting(xs, 0, 0)
+}
+
+// Counting sort for int8 in decreasing order.
+func Int8Desc(xs []int8) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint8_counting(xs, 1<<7, 0xFF)
+}
+
+// Counting sort for uint8 in decreasing order.
+func Uint8Desc(xs []uint8) {
+	if len(xs) <= 64 {
+		integer_insertion_desc(xs)
+		return
+	}
+	uint8_counting(xs, 0, 0xFF)
+}
+
+// Radix sort for int8 under the name of the 32 and 64bits APIs. Counting sort
+// is a radix sort on a single 8bits digit, hence both its MSD and LSD sorts.
+func Int8MSD(xs []int8) { Int8(xs) }
+
+// Radix sort for int8 under the name of the 32 and 64bits APIs, as Int8MSD.
+func Int8LSD(xs []int8) { Int8(xs) }
+
+// Radix sort for uint8 under the name of the 32 and 64bits APIs. Counting sort
+// is a radix sort on a single 8bits digit, hence both its MSD and LSD sorts.
+func Uint8MSD(xs []uint8) { Uint8(xs) }
+
+// Radix sort for uint8 under the name of the 32 and 64bits APIs, as Uint8MSD.
+func Uint8LSD(xs []uint8) { Uint8(xs) }
+
+// Radix sort for int8 in decreasing order, as Int8Desc.
+func Int8MSDDesc(xs []int8) { Int8Desc(xs) }
+
+// Radix sort for int8 in decreasing order, as Int8Desc.
+func Int8LSDDesc(xs []int8) { Int8Desc(xs) }
+
+// Radix sort for uint8 in decreasing order, as Uint8Desc.
+func Uint8MSDDesc(xs []uint8) { Uint8Desc(xs) }
+
+// Radix sort for uint8 in decreasing order, as Uint8Desc.
+func Uint8LSDDesc(xs []uint8) { Uint8Desc(xs) }
+
+// uint8_counting sorts xs by counting values with flip xored to their bits,
+// which is 1<<7 for signed order. Values are rewritten in increasing order if
+// mask is 0, and in decreasing order if mask is 0xFF.
+func uint8_counting[T ~int8 | ~uint8](xs []T, flip uint8, mask int) {
+	var cs [256]int
+	for _, x := range xs {
+		cs[uint8(x)^flip]++
+	}
+	lo := 0
+	for j := 0; j < 256; j++ {
+		var (
+			r  = j ^ mask
+			c  = cs[r]
+			x  = T(uint8(r) ^ flip)
+			zs = xs[lo : lo+c]
+		)
+		for i := range zs {
+			zs[i] = x
+		}
+		lo += c
+	}
+}
@@ -0,0 +1,99 @@
+package radixsort
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestInt8Sorting(t *testing.T) {
+	var (
+		sizes   = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorters = map[string]func([]int8){
+			"int8 counting sort":       Int8,
+			"int8 radix sort MSD":      Int8MSD,
+			"int8 radix sort LSD":      Int8LSD,
+			"int8 counting sort desc":  Int8Desc,
+			"int8 radix sort MSD desc": Int8MSDDesc,
+			"int8 radix sort LSD desc": Int8LSDDesc,
+		}
+		usorters = map[string]func([]uint8){
+			"uint8 counting sort":       Uint8,
+			"uint8 radix sort MSD":      Uint8MSD,
+			"uint8 radix sort LSD":      Uint8LSD,
+			"uint8 counting sort desc":  Uint8Desc,
+			"uint8 radix sort MSD desc": Uint8MSDDesc,
+			"uint8 radix sort LSD desc": Uint8LSDDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := generic_pop[int8](size)
+		for desc, s := range sorters {
+			ys := make([]int8, size)
+			copy(ys, xs)
+			s(ys)
+			less := func(i, j int) bool { return ys[i] < ys[j] }
+			if strings.HasSuffix(desc, "desc") {
+				less = func(i, j int) bool { return ys[i] > ys[j] }
+			}
+			if !sort.SliceIsSorted(ys, less) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+			int8_check_permutation(t, xs, ys)
+		}
+
+		us := generic_pop[uint8](size)
+		for desc, s := range usorters {
+			vs := make([]uint8, size)
+			copy(vs, us)
+			s(vs)
+			less := func(i, j int) bool { return vs[i] < vs[j] }
+			if strings.HasSuffix(desc, "desc") {
+				less = func(i, j int) bool { return vs[i] > vs[j] }
+			}
+			if !sort.SliceIsSorted(vs, less) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
+// int8_check_permutation checks that counting sort rewrote ys with the same
+// values as xs.
+func int8_check_permutation(t *testing.T, xs, ys []int8) {
+	var cs [256]int
+	for i := range xs {
+		cs[uint8(xs[i])]++
+		cs[uint8(ys[i])]--
+	}
+	for r, c := range cs {
+		if c != 0 {
+			t.Errorf("value %d was counted %d times too many in array of size %d", int8(r), c, len(xs))
+		}
+	}
+}
+
+func Benchmark_Int8_Counting_100(b *testing.B)    { benchmarkInt8(b, Int8, 100) }
+func Benchmark_Int8_Counting_1000(b *testing.B)   { benchmarkInt8(b, Int8, 1000) }
+func Benchmark_Int8_Counting_10000(b *testing.B)  { benchmarkInt8(b, Int8, 10000) }
+func Benchmark_Int8_Counting_100000(b *testing.B) { benchmarkInt8(b, Int8, 100000) }
+
+func Benchmark_Int8_StandardSort_100(b *testing.B)    { benchmarkInt8(b, int8_stdSort, 100) }
+func Benchmark_Int8_StandardSort_1000(b *testing.B)   { benchmarkInt8(b, int8_stdSort, 1000) }
+func Benchmark_Int8_StandardSort_10000(b *testing.B)  { benchmarkInt8(b, int8_stdSort, 10000) }
+func Benchmark_Int8_StandardSort_100000(b *testing.B) { benchmarkInt8(b, int8_stdSort, 100000) }
+
+func benchmarkInt8(b *testing.B, sorter func([]int8), size int) {
+	ys := make([][]int8, b.N)
+	for n := range ys {
+		ys[n] = generic_pop[int8](size)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+func int8_stdSort(xs []int8) {
+	sort.Slice(xs, func(i, j int) bool { return xs[i] < xs[j] })
+}
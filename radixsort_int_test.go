@@ -26,6 +26,20 @@ func TestIntSorting(t *testing.T) {
 	}
 }
 
+func TestIntDescSorting(t *testing.T) {
+	sizes := []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+	for _, size := range sizes {
+		xs := int_pop(size)
+		for n := 0; n < size; n += 2 { // add duplicates
+			xs[n] &= 0xFFFF
+		}
+		IntDesc(xs)
+		if !sort.SliceIsSorted(xs, func(i, j int) bool { return xs[i] > xs[j] }) {
+			t.Errorf("array of size %d was not correctly sorted by int radix sort desc", size)
+		}
+	}
+}
+
 func Benchmark_Int_Radix_100(b *testing.B)    { benchmarkInt(b, Int, 100) }
 func Benchmark_Int_Radix_1000(b *testing.B)   { benchmarkInt(b, Int, 1000) }
 func Benchmark_Int_Radix_10000(b *testing.B)  { benchmarkInt(b, Int, 10000) }
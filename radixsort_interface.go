@@ -0,0 +1,47 @@
+package radixsort
+
+import (
+	"sort"
+)
+
+// RadixInterface is a sort.Interface whose elements have a uint64 key, such
+// that Less(i, j) is Key(i) < Key(j). To sort by a signed key k, return
+// uint64(k) ^ 1<<63.
+type RadixInterface interface {
+	sort.Interface
+	// Key returns the key of the element with index i.
+	Key(i int) uint64
+}
+
+// SortInterface sorts data in increasing order of keys with radix sort, as a
+// drop-in replacement of sort.Stable. Key is called once per element, Swap at
+// most Len() - 1 times, and Less is never called. The sort is stable: elements
+// with equal keys keep their input order.
+func SortInterface(data RadixInterface) {
+	n := data.Len()
+	ks, is := make([]uint64, n), argsort_indices(n, make([]int32, n))
+	for i := range ks {
+		ks[i] = data.Key(i)
+	}
+	uint64_pairs(ks, is)
+	interface_permute(data, is)
+}
+
+// interface_permute moves the element of index is[j] of data to index j, with
+// one Swap per element out of place. Every cycle of the permutation is
+// followed from its first index i: the element of index is[j] is swapped into
+// index j, which leaves the element of index i at index is[j], where it
+// belongs once the cycle goes back to i. Indices of is are reset to their
+// position as they are placed.
+func interface_permute(data sort.Interface, is []int32) {
+	for i := range is {
+		j := i
+		for int(is[j]) != i {
+			k := int(is[j])
+			data.Swap(j, k)
+			is[j] = int32(j)
+			j = k
+		}
+		is[j] = int32(j)
+	}
+}
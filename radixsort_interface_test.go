@@ -0,0 +1,48 @@
+package radixsort
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+// records implements RadixInterface, and counts calls to Swap.
+type records struct {
+	xs    []record
+	swaps int
+}
+
+func (r *records) Len() int           { return len(r.xs) }
+func (r *records) Less(i, j int) bool { return r.xs[i].key < r.xs[j].key }
+func (r *records) Swap(i, j int)      { r.xs[i], r.xs[j] = r.xs[j], r.xs[i]; r.swaps++ }
+func (r *records) Key(i int) uint64   { return r.xs[i].key }
+
+func TestSortInterface(t *testing.T) {
+	var (
+		sizes = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+		masks = []uint64{^uint64(0), 0xFF, 0xFF00000000000F00}
+	)
+	for _, size := range sizes {
+		for _, mask := range masks {
+			xs := record_pop(size, mask)
+			want := slices.Clone(xs)
+			sort.Stable(&records{xs: want})
+
+			r := &records{xs: slices.Clone(xs)}
+			SortInterface(r)
+			if !slices.Equal(r.xs, want) {
+				t.Errorf("array of size %d with key mask %x was not stably sorted by SortInterface", size, mask)
+			}
+			if size > 0 && r.swaps >= size {
+				t.Errorf("array of size %d with key mask %x was sorted with %d swaps", size, mask, r.swaps)
+			}
+		}
+	}
+}
+
+func Benchmark_Record_SortInterface_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) { SortInterface(&records{xs: xs}) }, 100000)
+}
+func Benchmark_Record_StandardStable_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) { sort.Stable(&records{xs: xs}) }, 100000)
+}
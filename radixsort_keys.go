@@ -0,0 +1,159 @@
+package radixsort
+
+// Keys sorts the rows of a table stored as columns lexicographically, by
+// several integer columns of the same length. Columns are added with the
+// methods named after their type, in order of precedence, and in decreasing
+// order with the xxxDesc methods. The zero value has no column.
+//
+//	var k radixsort.Keys
+//	is := k.Uint32(tenants).Int64(timestamps).Uint16Desc(seqs).Argsort()
+type Keys struct {
+	rows    int
+	columns []keys_column
+}
+
+// keys_column writes the keys of rows is of a column into ks. Columns of 32bits
+// or less have keys32, and 64bits columns keys64.
+type keys_column struct {
+	keys32 func(is []int32, ks []uint32)
+	keys64 func(is []int32, ks []uint64)
+}
+
+// Int8 adds a column of int8 keys in increasing order.
+func (k *Keys) Int8(xs []int8) *Keys { return keys_add(k, xs, false) }
+
+// Uint8 adds a column of uint8 keys in increasing order.
+func (k *Keys) Uint8(xs []uint8) *Keys { return keys_add(k, xs, false) }
+
+// Int16 adds a column of int16 keys in increasing order.
+func (k *Keys) Int16(xs []int16) *Keys { return keys_add(k, xs, false) }
+
+// Uint16 adds a column of uint16 keys in increasing order.
+func (k *Keys) Uint16(xs []uint16) *Keys { return keys_add(k, xs, false) }
+
+// Int32 adds a column of int32 keys in increasing order.
+func (k *Keys) Int32(xs []int32) *Keys { return keys_add(k, xs, false) }
+
+// Uint32 adds a column of uint32 keys in increasing order.
+func (k *Keys) Uint32(xs []uint32) *Keys { return keys_add(k, xs, false) }
+
+// Int64 adds a column of int64 keys in increasing order.
+func (k *Keys) Int64(xs []int64) *Keys { return keys_add(k, xs, false) }
+
+// Uint64 adds a column of uint64 keys in increasing order.
+func (k *Keys) Uint64(xs []uint64) *Keys { return keys_add(k, xs, false) }
+
+// Int adds a column of int keys in increasing order.
+func (k *Keys) Int(xs []int) *Keys { return keys_add(k, xs, false) }
+
+// Uint adds a column of uint keys in increasing order.
+func (k *Keys) Uint(xs []uint) *Keys { return keys_add(k, xs, false) }
+
+// Int8Desc adds a column of int8 keys in decreasing order.
+func (k *Keys) Int8Desc(xs []int8) *Keys { return keys_add(k, xs, true) }
+
+// Uint8Desc adds a column of uint8 keys in decreasing order.
+func (k *Keys) Uint8Desc(xs []uint8) *Keys { return keys_add(k, xs, true) }
+
+// Int16Desc adds a column of int16 keys in decreasing order.
+func (k *Keys) Int16Desc(xs []int16) *Keys { return keys_add(k, xs, true) }
+
+// Uint16Desc adds a column of uint16 keys in decreasing order.
+func (k *Keys) Uint16Desc(xs []uint16) *Keys { return keys_add(k, xs, true) }
+
+// Int32Desc adds a column of int32 keys in decreasing order.
+func (k *Keys) Int32Desc(xs []int32) *Keys { return keys_add(k, xs, true) }
+
+// Uint32Desc adds a column of uint32 keys in decreasing order.
+func (k *Keys) Uint32Desc(xs []uint32) *Keys { return keys_add(k, xs, true) }
+
+// Int64Desc adds a column of int64 keys in decreasing order.
+func (k *Keys) Int64Desc(xs []int64) *Keys { return keys_add(k, xs, true) }
+
+// Uint64Desc adds a column of uint64 keys in decreasing order.
+func (k *Keys) Uint64Desc(xs []uint64) *Keys { return keys_add(k, xs, true) }
+
+// IntDesc adds a column of int keys in decreasing order.
+func (k *Keys) IntDesc(xs []int) *Keys { return keys_add(k, xs, true) }
+
+// UintDesc adds a column of uint keys in decreasing order.
+func (k *Keys) UintDesc(xs []uint) *Keys { return keys_add(k, xs, true) }
+
+// Argsort returns the permutation of rows that sorts the table: rows is[0],
+// is[1], ... are in increasing order of their keys. The permutation is stable,
+// indices of rows with equal keys are in increasing order.
+func (k *Keys) Argsort() []int32 {
+	is := make([]int32, k.rows)
+	k.ArgsortInto(is)
+	return is
+}
+
+// ArgsortInto writes the permutation of Argsort into the first rows elements
+// of is, and panics if is is too short.
+//
+// Columns are sorted from the last to the first one, each with a stable least
+// significant digit radix sort of the permutation by the keys of the column,
+// so that every column is a more significant digit of the keys of rows than
+// the next ones. Bytes shared by all keys of a column, such as the high bytes
+// of uint16 columns, are not scattered.
+func (k *Keys) ArgsortInto(is []int32) {
+	is = argsort_indices(k.rows, is)
+	var (
+		ks32 []uint32
+		ks64 []uint64
+	)
+	for c := len(k.columns) - 1; c >= 0; c-- {
+		column := k.columns[c]
+		if column.keys64 != nil {
+			if ks64 == nil {
+				ks64 = make([]uint64, len(is))
+			}
+			column.keys64(is, ks64)
+			uint64_pairs(ks64, is)
+		} else {
+			if ks32 == nil {
+				ks32 = make([]uint32, len(is))
+			}
+			column.keys32(is, ks32)
+			uint32_pairs(ks32, is)
+		}
+	}
+}
+
+// keys_add adds the column xs to k, with keys in increasing order of xs, or in
+// decreasing order if desc is set. Panics if xs does not have the length of
+// the columns of k.
+func keys_add[T Integer](k *Keys, xs []T, desc bool) *Keys {
+	if len(k.columns) > 0 && len(xs) != k.rows {
+		panic("radixsort: key columns have different lengths")
+	}
+	var (
+		width = 8 * integer_size[T]()
+		keys  = ^uint64(0) >> (64 - width) // drop sign extended bits
+		flip  uint64
+	)
+	if integer_signed[T]() {
+		flip = 1 << (width - 1) // signed order
+	}
+	if desc {
+		flip ^= keys // reverse the order of keys
+	}
+
+	var column keys_column
+	if width == 64 {
+		column.keys64 = func(is []int32, ks []uint64) {
+			for j, i := range is {
+				ks[j] = uint64(xs[i]) ^ flip
+			}
+		}
+	} else {
+		column.keys32 = func(is []int32, ks []uint32) {
+			for j, i := range is {
+				ks[j] = uint32((uint64(xs[i]) ^ flip) & keys)
+			}
+		}
+	}
+	k.rows = len(xs)
+	k.columns = append(k.columns, column)
+	return k
+}
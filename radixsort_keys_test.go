@@ -0,0 +1,128 @@
+package radixsort
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	for _, size := range []int{0, 1, 10, 1e3, 1e5} {
+		tbl := keys_table(size)
+		for _, desc := range [][3]bool{{false, false, false}, {false, true, false}, {true, false, true}} {
+			var k Keys
+			if desc[0] {
+				k.Uint32Desc(tbl.tenants)
+			} else {
+				k.Uint32(tbl.tenants)
+			}
+			if desc[1] {
+				k.Int64Desc(tbl.timestamps)
+			} else {
+				k.Int64(tbl.timestamps)
+			}
+			if desc[2] {
+				k.Int16Desc(tbl.seqs)
+			} else {
+				k.Int16(tbl.seqs)
+			}
+
+			want := make([]int32, size)
+			for i := range want {
+				want[i] = int32(i)
+			}
+			slices.SortStableFunc(want, func(i, j int32) int {
+				return compare_or(
+					keys_compare(tbl.tenants[i], tbl.tenants[j], desc[0]),
+					keys_compare(tbl.timestamps[i], tbl.timestamps[j], desc[1]),
+					keys_compare(tbl.seqs[i], tbl.seqs[j], desc[2]),
+				)
+			})
+			if is := k.Argsort(); !slices.Equal(is, want) {
+				t.Errorf("table of %d rows was not correctly sorted by keys in decreasing order %v", size, desc)
+			}
+		}
+	}
+
+	var (
+		xs = []int8{3, -1, 3, -128, 127}
+		ys = []uint{2, 9, 1, 0, 0}
+		is = make([]int32, len(xs)+2)
+	)
+	new(Keys).Int8(xs).UintDesc(ys).ArgsortInto(is)
+	if want := []int32{3, 1, 0, 2, 4}; !slices.Equal(is[:len(xs)], want) {
+		t.Errorf("int8 and uint keys were sorted as %v instead of %v", is[:len(xs)], want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Keys did not panic on columns of different lengths")
+		}
+	}()
+	new(Keys).Int8(xs).Uint(ys[1:])
+}
+
+func Benchmark_Keys_Argsort_100000(b *testing.B) {
+	tbl := keys_table(100000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		new(Keys).Uint32(tbl.tenants).Int64(tbl.timestamps).Int16(tbl.seqs).Argsort()
+	}
+}
+
+func Benchmark_Keys_SortStableFunc_100000(b *testing.B) {
+	tbl := keys_table(100000)
+	is := make([]int32, 100000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range is {
+			is[i] = int32(i)
+		}
+		slices.SortStableFunc(is, func(i, j int32) int {
+			return compare_or(
+				cmp.Compare(tbl.tenants[i], tbl.tenants[j]),
+				cmp.Compare(tbl.timestamps[i], tbl.timestamps[j]),
+				cmp.Compare(tbl.seqs[i], tbl.seqs[j]),
+			)
+		})
+	}
+}
+
+type keysTable struct {
+	tenants    []uint32
+	timestamps []int64
+	seqs       []int16
+}
+
+// keys_table returns a table of size rows with few tenants, duplicate
+// timestamps and negative sequence numbers.
+func keys_table(size int) keysTable {
+	tbl := keysTable{
+		tenants:    make([]uint32, size),
+		timestamps: make([]int64, size),
+		seqs:       make([]int16, size),
+	}
+	for i, x := range uint64_pop(size) {
+		tbl.tenants[i] = uint32(x % 7)
+		tbl.timestamps[i] = 1.6e9 + int64(x>>8%1000)
+		tbl.seqs[i] = int16(x >> 32)
+	}
+	return tbl
+}
+
+func keys_compare[T cmp.Ordered](x, y T, desc bool) int {
+	if desc {
+		return cmp.Compare(y, x)
+	}
+	return cmp.Compare(x, y)
+}
+
+// compare_or returns the first of cs that is not 0, like cmp.Or of Go 1.22.
+func compare_or(cs ...int) int {
+	for _, c := range cs {
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
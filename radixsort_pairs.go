@@ -0,0 +1,68 @@
+package radixsort
+
+// SortPairs sorts keys in increasing order and moves every vals[i] in lockstep
+// with keys[i], through the same scatter passes as the keys. Equal keys keep
+// their input order. SortPairs panics if keys and vals have different lengths.
+func SortPairs[K Integer, V any](keys []K, vals []V) {
+	if len(keys) != len(vals) {
+		panic("radixsort: keys and values have different lengths")
+	}
+	signed := integer_signed[K]()
+	switch integer_size[K]() {
+	case 8:
+		integer_as(keys, func(ks []uint64) {
+			if signed {
+				uint64_flip_sign(ks)
+				defer uint64_flip_sign(ks)
+			}
+			uint64_pairs(ks, vals)
+		})
+	case 4:
+		integer_as(keys, func(ks []uint32) {
+			if signed {
+				uint32_flip_sign(ks)
+				defer uint32_flip_sign(ks)
+			}
+			uint32_pairs(ks, vals)
+		})
+	default:
+		// widen to int32, then map signed order to unsigned order
+		ks := make([]uint32, len(keys))
+		for i, k := range keys {
+			ks[i] = uint32(int32(k)) ^ 1<<31
+		}
+		uint32_pairs(ks, vals)
+		for i, k := range ks {
+			keys[i] = K(int32(k ^ 1<<31))
+		}
+	}
+}
+
+// Paired radix sort for int32 keys and uint32 values.
+func Int32WithUint32(keys []int32, vals []uint32) { SortPairs(keys, vals) }
+
+// Paired radix sort for uint32 keys and uint32 values.
+func Uint32WithUint32(keys []uint32, vals []uint32) { SortPairs(keys, vals) }
+
+// Paired radix sort for int64 keys and uint32 values.
+func Int64WithUint32(keys []int64, vals []uint32) { SortPairs(keys, vals) }
+
+// Paired radix sort for uint64 keys and uint32 values.
+func Uint64WithUint32(keys []uint64, vals []uint32) { SortPairs(keys, vals) }
+
+// Paired radix sort for uint64 keys and uint64 values.
+func Uint64WithUint64(keys []uint64, vals []uint64) { SortPairs(keys, vals) }
+
+// uint32_flip_sign maps int32 order to uint32 order, and back.
+func uint32_flip_sign(xs []uint32) {
+	for i := range xs {
+		xs[i] ^= 1 << 31
+	}
+}
+
+// uint64_flip_sign maps int64 order to uint64 order, and back.
+func uint64_flip_sign(xs []uint64) {
+	for i := range xs {
+		xs[i] ^= 1 << 63
+	}
+}
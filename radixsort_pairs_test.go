@@ -0,0 +1,81 @@
+package radixsort
+
+import (
+	"testing"
+)
+
+func TestSortPairs(t *testing.T) {
+	sizes := []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+	for _, size := range sizes {
+		pairs_check[int8](t, "int8", size)
+		pairs_check[uint16](t, "uint16", size)
+		pairs_check[int32](t, "int32", size)
+		pairs_check[uint32](t, "uint32", size)
+		pairs_check[userID](t, "userID", size)
+		pairs_check[uint64](t, "uint64", size)
+	}
+
+	var (
+		keys = uint64_pop(1000)
+		vals = make([]uint32, len(keys))
+	)
+	for i := range vals {
+		vals[i] = uint32(keys[i])
+	}
+	Uint64WithUint32(keys, vals)
+	for i := range keys {
+		if uint32(keys[i]) != vals[i] || (i > 0 && keys[i-1] > keys[i]) {
+			t.Errorf("Uint64WithUint32 did not sort keys with their values at index %d", i)
+			break
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SortPairs did not panic on slices of different lengths")
+		}
+	}()
+	SortPairs(keys, vals[1:])
+}
+
+func Benchmark_Uint64_WithUint32_100000(b *testing.B) {
+	var (
+		keys = make([][]uint64, b.N)
+		vals = make([]uint32, 100000)
+	)
+	for n := range keys {
+		keys[n] = uint64_pop(len(vals))
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Uint64WithUint32(keys[n], vals)
+	}
+}
+
+// pairs_check sorts duplicate-heavy keys with their input indices as values,
+// and checks that values moved with their keys and kept their input order.
+func pairs_check[K Integer](t *testing.T, name string, size int) {
+	var (
+		xs   = generic_pop[K](size)
+		keys = make([]K, size)
+		vals = make([]int, size)
+	)
+	for i := range xs {
+		if i%2 == 0 {
+			xs[i] &= 0x7
+		}
+		keys[i] = xs[i]
+		vals[i] = i
+	}
+	SortPairs(keys, vals)
+	for i := range keys {
+		if keys[i] != xs[vals[i]] {
+			t.Errorf("%s pairs of size %d: key and value were separated at index %d", name, size, i)
+			return
+		}
+		if i > 0 && (keys[i-1] > keys[i] || (keys[i-1] == keys[i] && vals[i-1] > vals[i])) {
+			t.Errorf("%s pairs of size %d were not stably sorted at index %d", name, size, i)
+			return
+		}
+	}
+}
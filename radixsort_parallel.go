@@ -0,0 +1,233 @@
+package radixsort
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Arrays smaller than parallel_threshold are sorted by a single goroutine,
+// since starting goroutines would cost more than the sort itself.
+const parallel_threshold = 1 << 16
+
+// Parallel radix sort for int32 using up to workers goroutines.
+// Int32Parallel delegates to parallel least significant digit radix sort.
+// If workers is zero or less, runtime.GOMAXPROCS(0) goroutines are used.
+func Int32Parallel(xs []int32, workers int) { Int32LSDParallel(xs, workers) }
+
+// Parallel radix sort for uint32 using up to workers goroutines.
+// Uint32Parallel delegates to parallel least significant digit radix sort.
+// If workers is zero or less, runtime.GOMAXPROCS(0) goroutines are used.
+func Uint32Parallel(xs []uint32, workers int) { Uint32LSDParallel(xs, workers) }
+
+// Parallel radix sort for int64 using up to workers goroutines.
+// Int64Parallel delegates to parallel most significant digit radix sort.
+// If workers is zero or less, runtime.GOMAXPROCS(0) goroutines are used.
+func Int64Parallel(xs []int64, workers int) { Int64MSDParallel(xs, workers) }
+
+// Parallel radix sort for uint64 using up to workers goroutines.
+// Uint64Parallel delegates to parallel most significant digit radix sort.
+// If workers is zero or less, runtime.GOMAXPROCS(0) goroutines are used.
+func Uint64Parallel(xs []uint64, workers int) { Uint64MSDParallel(xs, workers) }
+
+// Parallel most significant digit radix sort for int32.
+func Int32MSDParallel(xs []int32, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Int32MSD(xs)
+		return
+	}
+	parallel_msd(xs, make([]int32, len(xs)), 1<<7, 32, 100, workers)
+}
+
+// Parallel most significant digit radix sort for uint32.
+func Uint32MSDParallel(xs []uint32, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Uint32MSD(xs)
+		return
+	}
+	parallel_msd(xs, make([]uint32, len(xs)), 0, 32, 100, workers)
+}
+
+// Parallel least significant digit radix sort for int32.
+func Int32LSDParallel(xs []int32, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Int32LSD(xs)
+		return
+	}
+	parallel_least_significant_digit(xs, make([]int32, len(xs)), 1<<7, 32, workers)
+}
+
+// Parallel least significant digit radix sort for uint32.
+func Uint32LSDParallel(xs []uint32, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Uint32LSD(xs)
+		return
+	}
+	parallel_least_significant_digit(xs, make([]uint32, len(xs)), 0, 32, workers)
+}
+
+// Parallel most significant digit radix sort for int64.
+func Int64MSDParallel(xs []int64, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Int64MSD(xs)
+		return
+	}
+	parallel_msd(xs, make([]int64, len(xs)), 1<<7, 64, 100, workers)
+}
+
+// Parallel most significant digit radix sort for uint64.
+func Uint64MSDParallel(xs []uint64, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Uint64MSD(xs)
+		return
+	}
+	parallel_msd(xs, make([]uint64, len(xs)), 0, 64, 100, workers)
+}
+
+// Parallel least significant digit radix sort for int64.
+func Int64LSDParallel(xs []int64, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Int64LSD(xs)
+		return
+	}
+	parallel_least_significant_digit(xs, make([]int64, len(xs)), 1<<7, 64, workers)
+}
+
+// Parallel least significant digit radix sort for uint64.
+func Uint64LSDParallel(xs []uint64, workers int) {
+	if workers = parallel_workers(len(xs), workers); workers == 1 {
+		Uint64LSD(xs)
+		return
+	}
+	parallel_least_significant_digit(xs, make([]uint64, len(xs)), 0, 64, workers)
+}
+
+// parallel_scatter moves xs into ys ordered by the digit at shift, taken like
+// in word_histogram. Every worker counts digits of its own chunk of xs, then
+// the per-worker counts are summed into per-worker offsets so that workers can
+// scatter their chunk concurrently without overlapping, preserving the input
+// order of equal digits. It returns the global digit counts.
+func parallel_scatter[T word, C counter](xs, ys []T, offset T, shift uint, workers int) [256]C {
+	css := make([][256]C, workers)
+	parallel_run(workers, func(w int) {
+		lo, hi := parallel_chunk(len(xs), workers, w)
+		cs := &css[w]
+		for _, x := range xs[lo:hi] {
+			cs[(offset+(x>>shift))&0xFF]++
+		}
+	})
+
+	var total [256]C
+	a := C(0)
+	for r := 0; r < 256; r++ {
+		for w := range css {
+			c := css[w][r]
+			css[w][r] = a
+			a += c
+			total[r] += c
+		}
+	}
+
+	parallel_run(workers, func(w int) {
+		lo, hi := parallel_chunk(len(xs), workers, w)
+		cs := css[w] // local copy of the offsets
+		for _, x := range xs[lo:hi] {
+			r := (offset + (x >> shift)) & 0xFF
+			ys[cs[r]] = x
+			cs[r]++
+		}
+	})
+	return total
+}
+
+// parallel_least_significant_digit sorts xs of width bits, 32 or 64, with
+// parallel_scatter on every digit position, using ys as swap space. offsetMSD
+// is added to the most significant digit, 1<<7 for signed order.
+func parallel_least_significant_digit[T word](xs, ys []T, offsetMSD T, width uint, workers int) {
+	for shift := uint(0); shift < width; shift += 8 {
+		offset := T(0)
+		if shift == width-8 {
+			offset = offsetMSD
+		}
+		if counter_wide(len(xs)) {
+			parallel_scatter[T, uint64](xs, ys, offset, shift, workers)
+		} else {
+			parallel_scatter[T, uint32](xs, ys, offset, shift, workers)
+		}
+		xs, ys = ys, xs // even number of swap
+	}
+}
+
+// parallel_msd sorts xs with parallel_most_significant_digit, using the counter
+// width suited to the length of xs.
+func parallel_msd[T word](xs, temp []T, offset T, width uint, cutoff, workers int) {
+	if counter_wide(len(xs)) {
+		parallel_most_significant_digit(xs, temp, offset, width, uint64(cutoff), workers)
+		return
+	}
+	parallel_most_significant_digit(xs, temp, offset, width, uint32(cutoff), workers)
+}
+
+// parallel_most_significant_digit scatters xs of width bits on the most
+// significant digit with all workers, then hands out the 256 buckets to the
+// workers, which sort them on the remaining width - 8 bits with
+// digits_most_significant_bucket. Buckets of cutoff elements or less are
+// sorted by insertion sort.
+func parallel_most_significant_digit[T word, C counter](xs, temp []T, offset T, width uint, cutoff C, workers int) {
+	cs := parallel_scatter[T, C](xs, temp, offset, width-8, workers)
+	parallel_run(workers, func(w int) {
+		lo, hi := parallel_chunk(len(xs), workers, w)
+		copy(xs[lo:hi], temp[lo:hi])
+	})
+
+	var (
+		next int32
+		los  [257]C
+	)
+	for i := 0; i < 256; i++ {
+		los[i+1] = los[i] + cs[i]
+	}
+	parallel_run(workers, func(w int) {
+		for {
+			i := atomic.AddInt32(&next, 1) - 1
+			if i >= 256 {
+				return
+			}
+			lo, hi := los[i], los[i+1]
+			digits_most_significant_bucket(xs[lo:hi], temp[lo:hi], 0, 0, width-8, 8, cutoff, nil)
+		}
+	})
+}
+
+// parallel_workers returns how many goroutines should sort n elements.
+func parallel_workers(n, workers int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if n < parallel_threshold {
+		return 1
+	}
+	return min(workers, n>>12) // at least 4096 elements per worker
+}
+
+// parallel_run calls f(w) for w in [0, workers) concurrently and waits for all
+// calls to return.
+func parallel_run(workers int, f func(w int)) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			f(w)
+		}(w)
+	}
+	wg.Wait()
+}
+
+// parallel_chunk returns the bounds of the w-th of workers chunks of n elements.
+func parallel_chunk(n, workers, w int) (lo, hi int) {
+	size := (n + workers - 1) / workers
+	lo = min(w*size, n)
+	hi = min(lo+size, n)
+	return lo, hi
+}
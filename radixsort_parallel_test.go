@@ -0,0 +1,83 @@
+package radixsort
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestParallelSorting(t *testing.T) {
+	var (
+		sizes   = []int{0, 1, 10, 1e3, parallel_threshold - 1, parallel_threshold, 1e6 + 7}
+		workers = []int{0, 1, 3, 8}
+	)
+	for _, size := range sizes {
+		var (
+			i32 = int32_pop(size)
+			u32 = uint32_pop(size)
+			i64 = int64_pop(size)
+			u64 = uint64_pop(size)
+		)
+		for _, w := range workers {
+			int32s := map[string]func([]int32, int){
+				"int32 parallel MSD": Int32MSDParallel,
+				"int32 parallel LSD": Int32LSDParallel,
+			}
+			for desc, s := range int32s {
+				ys := append([]int32(nil), i32...)
+				s(ys, w)
+				if !sort.IsSorted(byInt32(ys)) {
+					t.Errorf("array of size %d was not correctly sorted by %s with %d workers", size, desc, w)
+				}
+			}
+			uint32s := map[string]func([]uint32, int){
+				"uint32 parallel MSD": Uint32MSDParallel,
+				"uint32 parallel LSD": Uint32LSDParallel,
+			}
+			for desc, s := range uint32s {
+				ys := append([]uint32(nil), u32...)
+				s(ys, w)
+				if !sort.IsSorted(byUint32(ys)) {
+					t.Errorf("array of size %d was not correctly sorted by %s with %d workers", size, desc, w)
+				}
+			}
+			int64s := map[string]func([]int64, int){
+				"int64 parallel MSD": Int64MSDParallel,
+				"int64 parallel LSD": Int64LSDParallel,
+			}
+			for desc, s := range int64s {
+				ys := append([]int64(nil), i64...)
+				s(ys, w)
+				if !sort.IsSorted(byInt64(ys)) {
+					t.Errorf("array of size %d was not correctly sorted by %s with %d workers", size, desc, w)
+				}
+			}
+			uint64s := map[string]func([]uint64, int){
+				"uint64 parallel MSD": Uint64MSDParallel,
+				"uint64 parallel LSD": Uint64LSDParallel,
+			}
+			for desc, s := range uint64s {
+				ys := append([]uint64(nil), u64...)
+				s(ys, w)
+				if !sort.IsSorted(byUint64(ys)) {
+					t.Errorf("array of size %d was not correctly sorted by %s with %d workers", size, desc, w)
+				}
+			}
+		}
+	}
+}
+
+func Benchmark_Uint64_ParallelMSD_1000000(b *testing.B) {
+	benchmarkUint64(b, func(xs []uint64) { Uint64MSDParallel(xs, 0) }, 1000000)
+}
+func Benchmark_Uint64_ParallelLSD_1000000(b *testing.B) {
+	benchmarkUint64(b, func(xs []uint64) { Uint64LSDParallel(xs, 0) }, 1000000)
+}
+func Benchmark_Uint64_RadixMSD_1000000(b *testing.B) { benchmarkUint64(b, Uint64MSD, 1000000) }
+
+func Benchmark_Uint32_ParallelMSD_1000000(b *testing.B) {
+	benchmarkUint32(b, func(xs []uint32) { Uint32MSDParallel(xs, 0) }, 1000000)
+}
+func Benchmark_Uint32_ParallelLSD_1000000(b *testing.B) {
+	benchmarkUint32(b, func(xs []uint32) { Uint32LSDParallel(xs, 0) }, 1000000)
+}
+func Benchmark_Uint32_RadixLSD_1000000(b *testing.B) { benchmarkUint32(b, Uint32LSD, 1000000) }
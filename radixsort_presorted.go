@@ -0,0 +1,67 @@
+package radixsort
+
+import (
+	"slices"
+)
+
+// presorted_sort sorts xs without radix sort when it is already in increasing
+// order, in decreasing order, or in increasing order but for a tail of at most
+// 1/8 of its elements. The tail is sorted by sort and merged into the sorted
+// prefix using the swap space of s, unless s sorts in place. It reports
+// whether xs was sorted.
+func presorted_sort[T word](s *Sorter, xs []T, offset T, sort func(*Sorter, []T, T)) bool {
+	n := len(xs)
+	p := presorted_prefix(xs)
+	switch {
+	case p == n: // already sorted
+		return true
+	case presorted_decreasing(xs):
+		slices.Reverse(xs)
+		return true
+	case s.Algorithm == InPlace || n-p > n/8:
+		return false
+	}
+	sort(s, xs[p:], offset)
+	presorted_merge(xs, p, sorter_temp[T](s, n-p))
+	return true
+}
+
+// presorted_prefix returns the length of the longest prefix of xs in increasing
+// order.
+func presorted_prefix[T Integer](xs []T) int {
+	for i := 1; i < len(xs); i++ {
+		if xs[i-1] > xs[i] {
+			return i
+		}
+	}
+	return len(xs)
+}
+
+// presorted_decreasing reports whether xs is in decreasing order.
+func presorted_decreasing[T Integer](xs []T) bool {
+	for i := 1; i < len(xs); i++ {
+		if xs[i-1] < xs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// presorted_merge merges the sorted xs[:p] and xs[p:] from the end of xs,
+// using temp as swap space for xs[p:].
+func presorted_merge[T Integer](xs []T, p int, temp []T) {
+	copy(temp, xs[p:])
+	var (
+		i = p - 1
+		j = len(xs) - p - 1
+	)
+	for k := len(xs) - 1; j >= 0; k-- {
+		if i >= 0 && xs[i] > temp[j] {
+			xs[k] = xs[i]
+			i--
+		} else {
+			xs[k] = temp[j]
+			j--
+		}
+	}
+}
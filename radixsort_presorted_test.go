@@ -0,0 +1,120 @@
+package radixsort
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPresorted(t *testing.T) {
+	var (
+		sizes   = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+		sorters = map[string]*Sorter{
+			"auto":     {Presorted: true},
+			"MSD":      {Presorted: true, Algorithm: MSD},
+			"LSD":      {Presorted: true, Algorithm: LSD},
+			"in place": {Presorted: true, Algorithm: InPlace},
+		}
+	)
+	for _, size := range sizes {
+		for dist, pop := range presorted_pops {
+			xs := pop(size)
+			for desc, s := range sorters {
+				presorted_check(t, desc+" int64 "+dist, s.Int64, xs)
+				presorted_check(t, desc+" uint64 "+dist, s.Uint64, presorted_as[uint64](xs))
+				presorted_check(t, desc+" int32 "+dist, s.Int32, presorted_as[int32](xs))
+				presorted_check(t, desc+" uint32 "+dist, s.Uint32, presorted_as[uint32](xs))
+			}
+		}
+	}
+}
+
+func Benchmark_Int64_RadixMSDSorted_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD}, "sorted")
+}
+func Benchmark_Int64_PresortedSorted_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD, Presorted: true}, "sorted")
+}
+func Benchmark_Int64_RadixMSDReversed_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD}, "reversed")
+}
+func Benchmark_Int64_PresortedReversed_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD, Presorted: true}, "reversed")
+}
+func Benchmark_Int64_RadixMSDAppended_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD}, "appended")
+}
+func Benchmark_Int64_PresortedAppended_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD, Presorted: true}, "appended")
+}
+func Benchmark_Int64_RadixMSDRandom_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD}, "random")
+}
+func Benchmark_Int64_PresortedRandom_100000(b *testing.B) {
+	benchmarkPresorted(b, &Sorter{Algorithm: MSD, Presorted: true}, "random")
+}
+
+// benchmarkPresorted sorts copies of a single array, since presorted arrays are
+// sorted too fast to generate one array per iteration.
+func benchmarkPresorted(b *testing.B, s *Sorter, dist string) {
+	var (
+		xs = presorted_pops[dist](100000)
+		ys = make([]int64, len(xs))
+	)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		copy(ys, xs)
+		b.StartTimer()
+		s.Int64(ys)
+	}
+}
+
+// presorted_pops generates arrays of int64 in increasing order, in decreasing
+// order, in increasing order with 1% of random elements appended, and random.
+var presorted_pops = map[string]func(int) []int64{
+	"sorted": func(size int) []int64 {
+		xs := int64_pop(size)
+		slices.Sort(xs)
+		return xs
+	},
+	"reversed": func(size int) []int64 {
+		xs := int64_pop(size)
+		slices.Sort(xs)
+		slices.Reverse(xs)
+		return xs
+	},
+	"appended": func(size int) []int64 {
+		xs := int64_pop(size)
+		slices.Sort(xs[:size-size/100])
+		return xs
+	},
+	"random": int64_pop,
+}
+
+func presorted_check[T word](t *testing.T, name string, sorter func([]T), xs []T) {
+	var (
+		ys   = slices.Clone(xs)
+		want = slices.Clone(xs)
+	)
+	slices.Sort(want)
+	sorter(ys)
+	if !slices.Equal(ys, want) {
+		t.Errorf("array of size %d was not correctly sorted by %s", len(xs), name)
+	}
+}
+
+// presorted_as converts xs to T, keeping the order of xs in T for arrays sorted
+// in increasing or decreasing order.
+func presorted_as[T word](xs []int64) []T {
+	var (
+		ys    = make([]T, len(xs))
+		width = 8 * integer_size[T]()
+	)
+	for i, x := range xs {
+		ys[i] = T(x >> (64 - width))
+		if !integer_signed[T]() {
+			ys[i] ^= T(1) << (width - 1)
+		}
+	}
+	return ys
+}
@@ -0,0 +1,57 @@
+//go:build purego
+
+package radixsort
+
+import (
+	"math"
+)
+
+// integer_size returns the size of T in bytes, as the smallest size that
+// truncates away the bit just above it when converting to T.
+func integer_size[T Integer]() uintptr {
+	for n := uintptr(1); n < 8; n *= 2 {
+		if T(uint64(1)<<(8*n)) == 0 {
+			return n
+		}
+	}
+	return 8
+}
+
+// integer_as sorts xs with sorter by converting xs to a slice of U and back. U
+// must have the same size as T, so that conversions keep the bits of elements.
+func integer_as[T, U Integer](xs []T, sorter func([]U)) {
+	us := make([]U, len(xs))
+	for i, x := range xs {
+		us[i] = U(x)
+	}
+	sorter(us)
+	for i, u := range us {
+		xs[i] = T(u)
+	}
+}
+
+// float32_as sorts the bits of xs with sorter by converting xs to a slice of
+// uint32 and back.
+func float32_as(xs []float32, sorter func([]uint32)) {
+	us := make([]uint32, len(xs))
+	for i, x := range xs {
+		us[i] = math.Float32bits(x)
+	}
+	sorter(us)
+	for i, u := range us {
+		xs[i] = math.Float32frombits(u)
+	}
+}
+
+// float64_as sorts the bits of xs with sorter by converting xs to a slice of
+// uint64 and back.
+func float64_as(xs []float64, sorter func([]uint64)) {
+	us := make([]uint64, len(xs))
+	for i, x := range xs {
+		us[i] = math.Float64bits(x)
+	}
+	sorter(us)
+	for i, u := range us {
+		xs[i] = math.Float64frombits(u)
+	}
+}
@@ -0,0 +1,96 @@
+package radixsort
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"math"
+	"testing"
+)
+
+// digest_want is the digest of TestPureGoDigest outputs. It is the same with
+// and without the purego build tag: both builds sort byte for byte identically.
+const digest_want = "b6cf30e10c8cc777098494e9dfe346421713dcce103c5204e6a863431b55a7db"
+
+// TestPureGoDigest hashes the outputs of the sorts on the same inputs from a
+// xs64s generator, and compares the digest to digest_want.
+func TestPureGoDigest(t *testing.T) {
+	var (
+		r = xs64s(42)
+		h = sha256.New()
+	)
+	for _, size := range []int{10, 1e3, 1e5} {
+		digest_sorts(h, &r, size)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != digest_want {
+		t.Errorf("digest of sorted outputs is %s, want %s", got, digest_want)
+	}
+}
+
+func digest_sorts(h hash.Hash, r *xs64s, size int) {
+	write := func(data any) { binary.Write(h, binary.LittleEndian, data) }
+
+	for _, s := range []func([]int8){Int8, Sort[int8]} {
+		xs := digest_pop(r, size, func(u uint64) int8 { return int8(u) })
+		s(xs)
+		write(xs)
+	}
+	for _, s := range []func([]uint16){Uint16, Uint16LSD, Uint16Counting, SortLSD[uint16]} {
+		xs := digest_pop(r, size, func(u uint64) uint16 { return uint16(u) })
+		s(xs)
+		write(xs)
+	}
+	for _, s := range []func([]int32){Int32MSD, Int32LSD, Int32InPlace, Int32Desc, (&Sorter{}).Int32} {
+		xs := digest_pop(r, size, func(u uint64) int32 { return int32(u) })
+		s(xs)
+		write(xs)
+	}
+	for _, s := range []func([]uint64){Uint64MSD, Uint64LSD, Uint64InPlace, Uint64MSDDesc, SortMSD[uint64]} {
+		xs := digest_pop(r, size, func(u uint64) uint64 { return u })
+		s(xs)
+		write(xs)
+	}
+	for _, s := range []func([]userID){Sort[userID], SortLSD[userID]} {
+		xs := digest_pop(r, size, func(u uint64) userID { return userID(u) })
+		s(xs)
+		write(xs)
+	}
+
+	is := digest_pop(r, size, func(u uint64) int { return int(int32(u)) })
+	Int(is)
+	for _, x := range is {
+		write(int64(x))
+	}
+	us := digest_pop(r, size, func(u uint64) uint { return uint(uint32(u)) })
+	(&Sorter{Algorithm: InPlace}).Uint(us)
+	for _, x := range us {
+		write(uint64(x))
+	}
+
+	for _, s := range []func([]float32){Float32, Float32TotalOrder} {
+		xs := digest_pop(r, size, func(u uint64) float32 { return math.Float32frombits(uint32(u)) })
+		s(xs)
+		write(xs)
+	}
+	for _, s := range []func([]float64){Float64, Float64TotalOrder, (&Sorter{}).Float64} {
+		xs := digest_pop(r, size, func(u uint64) float64 { return math.Float64frombits(u) })
+		s(xs)
+		write(xs)
+	}
+
+	ks := digest_pop(r, size, func(u uint64) int64 { return int64(u % 64) })
+	vs := digest_pop(r, size, func(u uint64) uint32 { return uint32(u) })
+	SortPairs(ks, vs)
+	write(ks)
+	write(vs)
+	write(Int64Argsort(digest_pop(r, size, func(u uint64) int64 { return int64(u % 64) })))
+}
+
+func digest_pop[T any](r *xs64s, size int, f func(uint64) T) []T {
+	xs := make([]T, size)
+	for i := range xs {
+		xs[i] = f(r.next())
+	}
+	return xs
+}
@@ -0,0 +1,75 @@
+package radixsort
+
+import (
+	"math/bits"
+)
+
+// Radix sort for uint64 values known to lie in [min, max]. Values are sorted
+// on the bits of x - min that vary between min and max only, without scanning
+// xs for its range. Panics if min is greater than max. Values outside
+// [min, max] leave xs in an unspecified order.
+func Uint64Range(xs []uint64, min, max uint64) {
+	if min > max {
+		panic("radixsort: range minimum is greater than its maximum")
+	}
+	if len(xs) <= 64 {
+		integer_insertion(xs)
+		return
+	}
+	range_digits(xs, make([]uint64, len(xs)), 0, min, uint(bits.Len64(max-min)), 100, 0, nil)
+}
+
+// range_sort sorts xs with range_digits on the bits of keys that vary between
+// the smallest and largest keys of xs, using temp as swap space. It reports
+// false, leaving xs unsorted, when the range of keys spans as many 8bits
+// digits as keys of width bits.
+func range_sort[T word](xs, temp []T, flip uint64, width uint, cutoff int, bits uint, tables *digits_tables) bool {
+	lo, span := range_keys(xs, flip, width)
+	if (span+7)/8 == (width+7)/8 {
+		return false
+	}
+	range_digits(xs, temp, flip, lo, span, cutoff, bits, tables)
+	return true
+}
+
+// range_digits sorts xs on the span low bits of keys minus lo, using temp as
+// swap space. Keys of 32 bits or less are sorted with least significant digit
+// radix sort like Int32, and wider keys with most significant digit radix sort
+// like Int64. Digits are bits wide, or selected from the length of xs if bits
+// is 0. 16bits digits take their count tables from tables.
+func range_digits[T word](xs, temp []T, flip, lo uint64, span uint, cutoff int, bits uint, tables *digits_tables) {
+	switch {
+	case span == 0: // all keys are equal
+	case span <= 32:
+		if bits == 0 {
+			bits = digit_bits_lsd(len(xs), span)
+		}
+		digits_lsd(xs, temp, flip, lo, span, bits, tables)
+	default:
+		if bits == 0 {
+			bits = digit_bits_msd(len(xs))
+		}
+		digits_msd(xs, temp, flip, lo, span, bits, cutoff, tables)
+	}
+}
+
+// range_keys returns the smallest key of xs, taken as uint64(x) ^ flip on the
+// width low bits of x, and the number of bits of its difference with the
+// largest key.
+func range_keys[T word](xs []T, flip uint64, width uint) (lo uint64, span uint) {
+	var (
+		keys = ^uint64(0) >> (64 - width) // drop sign extended bits of int32
+		k    = (uint64(xs[0]) ^ flip) & keys
+	)
+	lo, hi := k, k
+	for _, x := range xs[1:] {
+		k := (uint64(x) ^ flip) & keys
+		if k < lo {
+			lo = k
+		}
+		if k > hi {
+			hi = k
+		}
+	}
+	return lo, uint(bits.Len64(hi - lo))
+}
@@ -0,0 +1,103 @@
+package radixsort
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRangeSorting(t *testing.T) {
+	sizes := []int{10, 1e3, 1e5}
+	for _, size := range sizes {
+		range_check(t, "Int64 IDs", Int64, 1e9, 1e6, size)
+		range_check(t, "Int64 timestamps", Int64, 1.6e18, 1<<40, size)
+		range_check(t, "Int64 around zero", Int64, -1000, 2000, size)
+		range_check(t, "Int64 lowest", Int64, math.MinInt64, 10, size)
+		range_check(t, "Int64 wide", Int64, -1<<62, 1<<63, size)
+		range_check(t, "Int64 equal", Int64, 42, 1, size)
+		range_check(t, "Uint64 byte boundary", Uint64, 0xFFFF, 2, size)
+		range_check(t, "Uint64 highest", Uint64, math.MaxUint64-1e4, 1e4, size)
+		range_check(t, "Int32 IDs", Int32, 1e9, 1e6, size)
+		range_check(t, "Int32 around zero", Int32, -1000, 2000, size)
+		range_check(t, "Int32 lowest", Int32, math.MinInt32, 300, size)
+		range_check(t, "Uint32 highest", Uint32, math.MaxUint32-1e5, 1e5, size)
+		range_check(t, "Sort userID", Sort[userID], 1e12, 1e9, size)
+		range_check(t, "Int around zero", Int, -1e5, 2e5, size)
+
+		for _, digits := range []int{0, 8, 11, 16} {
+			s := &Sorter{DigitBits: digits}
+			range_check(t, "Sorter Int64", s.Int64, -1e7, 2e7, size)
+			range_check(t, "Sorter Uint32", s.Uint32, 1e9, 1e6, size)
+		}
+	}
+}
+
+func TestUint64Range(t *testing.T) {
+	for _, size := range []int{10, 1e3, 1e5} {
+		xs := uint64_pop(size)
+		for i := range xs {
+			xs[i] = 1e9 + xs[i]%1e6
+		}
+		want := slices.Clone(xs)
+		slices.Sort(want)
+
+		for _, bounds := range [][2]uint64{{1e9, 1e9 + 1e6}, {0, 1e10}, {want[0], want[size-1]}} {
+			ys := slices.Clone(xs)
+			Uint64Range(ys, bounds[0], bounds[1])
+			if !slices.Equal(ys, want) {
+				t.Errorf("array of size %d in [%d, %d] was not correctly sorted by Uint64Range", size, bounds[0], bounds[1])
+			}
+		}
+
+		ys := slices.Clone(xs)
+		Uint64Range(ys, 1e9+1e5, 1e9+2e5) // values out of range are only permuted
+		slices.Sort(ys)
+		if !slices.Equal(ys, want) {
+			t.Errorf("array of size %d out of range was not permuted by Uint64Range", size)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Uint64Range did not panic on a minimum greater than the maximum")
+		}
+	}()
+	Uint64Range(make([]uint64, 100), 2, 1)
+}
+
+func Benchmark_Uint64_RadixMSDNarrow_100000(b *testing.B) { benchmarkNarrow(b, Uint64MSD) }
+func Benchmark_Uint64_RadixLSDNarrow_100000(b *testing.B) { benchmarkNarrow(b, Uint64LSD) }
+func Benchmark_Uint64_RadixNarrow_100000(b *testing.B)    { benchmarkNarrow(b, Uint64) }
+func Benchmark_Uint64_RadixRange_100000(b *testing.B) {
+	benchmarkNarrow(b, func(xs []uint64) { Uint64Range(xs, 1e9, 1e9+1e6) })
+}
+
+// benchmarkNarrow sorts arrays of 100000 IDs in [1e9, 1e9+1e6].
+func benchmarkNarrow(b *testing.B, sorter func([]uint64)) {
+	ys := make([][]uint64, b.N)
+	for n := range ys {
+		ys[n] = uint64_pop(100000)
+		for i, y := range ys[n] {
+			ys[n][i] = 1e9 + y%1e6
+		}
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+// range_check sorts size values in [lo, lo+span) with sorter, and compares them
+// with the values sorted by slices.Sort.
+func range_check[T Integer](t *testing.T, name string, sorter func([]T), lo T, span uint64, size int) {
+	xs := make([]T, size)
+	for i, x := range generic_pop[uint64](size) {
+		xs[i] = lo + T(x%span)
+	}
+	want := slices.Clone(xs)
+	slices.Sort(want)
+	sorter(xs)
+	if !slices.Equal(xs, want) {
+		t.Errorf("array of size %d in range [%d, %d+%d) was not correctly sorted by %s", size, lo, lo, span, name)
+	}
+}
@@ -0,0 +1,133 @@
+package radixsort
+
+import (
+	"slices"
+)
+
+// Radix selection for int32. Int32Select reorders xs so that xs[n] is the
+// element of rank n in increasing order, with smaller or equal elements before
+// it and greater or equal elements after it, and returns xs[n]. Panics if n is
+// not a valid index of xs.
+func Int32Select(xs []int32, n int) int32 { return select_rank(xs, n, 1<<7, 32) }
+
+// Radix selection for uint32. Uint32Select reorders xs so that xs[n] is the
+// element of rank n in increasing order, with smaller or equal elements before
+// it and greater or equal elements after it, and returns xs[n]. Panics if n is
+// not a valid index of xs.
+func Uint32Select(xs []uint32, n int) uint32 { return select_rank(xs, n, 0, 32) }
+
+// Radix selection for int64. Int64Select reorders xs so that xs[n] is the
+// element of rank n in increasing order, with smaller or equal elements before
+// it and greater or equal elements after it, and returns xs[n]. Panics if n is
+// not a valid index of xs.
+func Int64Select(xs []int64, n int) int64 { return select_rank(xs, n, 1<<7, 64) }
+
+// Radix selection for uint64. Uint64Select reorders xs so that xs[n] is the
+// element of rank n in increasing order, with smaller or equal elements before
+// it and greater or equal elements after it, and returns xs[n]. Panics if n is
+// not a valid index of xs.
+func Uint64Select(xs []uint64, n int) uint64 { return select_rank(xs, n, 0, 64) }
+
+// Quantiles returns the quantiles qs of xs, in the order of qs. The quantile q
+// is the element of rank int(q * (len(xs)-1)) in increasing order, so that 0
+// is the smallest element, 0.5 the lower median and 1 the largest element. xs
+// is reordered so that each of these elements is at the index of its rank, as
+// by xxxSelect. Panics if xs is empty or if a quantile is not in [0, 1].
+func Quantiles[T Integer](xs []T, qs []float64) []T {
+	if len(xs) == 0 {
+		panic("radixsort: quantiles of an empty slice")
+	}
+	ranks := make([]int, len(qs))
+	for i, q := range qs {
+		if !(q >= 0 && q <= 1) { // also rejects NaN
+			panic("radixsort: quantile is not in [0, 1]")
+		}
+		ranks[i] = int(q * float64(len(xs)-1))
+	}
+	rs := slices.Clone(ranks)
+	slices.Sort(rs)
+	rs = slices.Compact(rs)
+
+	signed := integer_signed[T]()
+	switch integer_size[T]() {
+	case 8:
+		if signed {
+			integer_as(xs, func(ys []int64) { select_ranks(ys, rs, 1<<7, 64) })
+		} else {
+			integer_as(xs, func(ys []uint64) { select_ranks(ys, rs, 0, 64) })
+		}
+	case 4:
+		if signed {
+			integer_as(xs, func(ys []int32) { select_ranks(ys, rs, 1<<7, 32) })
+		} else {
+			integer_as(xs, func(ys []uint32) { select_ranks(ys, rs, 0, 32) })
+		}
+	default: // counting sort is as fast as selection
+		Sort(xs)
+	}
+
+	vs := make([]T, len(ranks))
+	for i, r := range ranks {
+		vs[i] = xs[r]
+	}
+	return vs
+}
+
+// select_rank reorders xs so that xs[n] is the element of rank n, and returns
+// it. Digits of width bits values are taken like in partial_sort.
+func select_rank[T word](xs []T, n int, offset T, width uint) T {
+	if n < 0 || n >= len(xs) {
+		panic("radixsort: rank is out of range")
+	}
+	select_partition(xs, n, offset, width)
+	return xs[n]
+}
+
+// select_partition reorders xs so that xs[n] is the element of rank n, with
+// partial_partition on every digit position from the most significant one.
+// Only the bucket holding the element of rank n is partitioned on the next
+// digit, and its elements are sorted by insertion sort once there are 64 or
+// less of them.
+func select_partition[T word](xs []T, n int, offset T, width uint) {
+	if counter_wide(len(xs)) {
+		select_digits[T, uint64](xs, n, offset, width)
+		return
+	}
+	select_digits[T, uint32](xs, n, offset, width)
+}
+
+// select_digits is select_partition with counters of type C.
+func select_digits[T word, C counter](xs []T, n int, offset T, width uint) {
+	for shift := width - 8; ; shift -= 8 {
+		if len(xs) <= 64 {
+			integer_insertion(xs)
+			return
+		}
+		lo, hi := partial_partition[T, C](xs, n, offset, shift, 0)
+		if shift == 0 { // elements of the bucket are equal
+			return
+		}
+		xs, n, offset = xs[lo:hi], n-lo, 0
+	}
+}
+
+// select_ranks reorders xs so that xs[r] is the element of rank r for every r
+// of ranks, which are sorted and distinct. The median of ranks is selected
+// first, then smaller ranks are selected in front of it and greater ranks
+// behind it.
+func select_ranks[T word](xs []T, ranks []int, offset T, width uint) {
+	if len(ranks) == 0 {
+		return
+	}
+	var (
+		m = len(ranks) / 2
+		r = ranks[m]
+	)
+	select_partition(xs, r, offset, width)
+	select_ranks(xs[:r], ranks[:m], offset, width)
+	after := ranks[m+1:]
+	for i := range after {
+		after[i] -= r + 1
+	}
+	select_ranks(xs[r+1:], after, offset, width)
+}
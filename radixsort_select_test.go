@@ -0,0 +1,100 @@
+package radixsort
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	sizes := []int{1, 2, 10, 1e2, 1e3, 1e4}
+	for _, size := range sizes {
+		for _, n := range []int{0, 1, size / 4, size / 2, size - 1} {
+			if n >= size {
+				continue
+			}
+			select_check(t, "Int32Select", Int32Select, size, n)
+			select_check(t, "Uint32Select", Uint32Select, size, n)
+			select_check(t, "Int64Select", Int64Select, size, n)
+			select_check(t, "Uint64Select", Uint64Select, size, n)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Int64Select did not panic on an out of range rank")
+		}
+	}()
+	Int64Select(make([]int64, 10), 10)
+}
+
+func TestQuantiles(t *testing.T) {
+	qs := []float64{0.99, 0, 0.5, 0.25, 0.5, 1, 0.9, 0.999}
+	for _, size := range []int{1, 2, 10, 1e3, 1e5} {
+		quantiles_check[int64](t, "int64", size, qs)
+		quantiles_check[uint32](t, "uint32", size, qs)
+		quantiles_check[int](t, "int", size, qs)
+		quantiles_check[int16](t, "int16", size, qs)
+		quantiles_check[userID](t, "userID", size, qs)
+	}
+
+	for _, qs := range [][]float64{{-0.1}, {1.5}, {math.NaN()}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Quantiles did not panic on quantile %v", qs[0])
+				}
+			}()
+			Quantiles(make([]int64, 10), qs)
+		}()
+	}
+}
+
+func Benchmark_Int64_Select_1000000(b *testing.B) {
+	benchmarkInt64(b, func(xs []int64) { Int64Select(xs, len(xs)/2) }, 1000000)
+}
+func Benchmark_Int64_Quantiles_1000000(b *testing.B) {
+	qs := []float64{0.5, 0.9, 0.99, 0.999}
+	benchmarkInt64(b, func(xs []int64) { Quantiles(xs, qs) }, 1000000)
+}
+func Benchmark_Int64_RadixMSD_1000000(b *testing.B) { benchmarkInt64(b, Int64MSD, 1000000) }
+
+// select_check selects the element of rank n of arrays with random values and
+// with many duplicates, and checks that xs is partitioned around it.
+func select_check[T word](t *testing.T, name string, sel func([]T, int) T, size, n int) {
+	for _, mask := range []T{^T(0), 0xF0F} {
+		xs := generic_pop[T](size)
+		for i := range xs {
+			xs[i] &= mask
+		}
+		want := slices.Clone(xs)
+		slices.Sort(want)
+
+		x := sel(xs, n)
+		if x != want[n] || xs[n] != want[n] {
+			t.Errorf("%s selected %d instead of %d at rank %d of %d elements", name, x, want[n], n, size)
+		}
+		if slices.Max(xs[:n+1]) != x || slices.Min(xs[n:]) != x {
+			t.Errorf("%s did not partition %d elements around rank %d", name, size, n)
+		}
+		slices.Sort(xs)
+		if !slices.Equal(xs, want) {
+			t.Errorf("%s lost elements of an array of size %d", name, size)
+		}
+	}
+}
+
+func quantiles_check[T Integer](t *testing.T, name string, size int, qs []float64) {
+	var (
+		xs   = generic_pop[T](size)
+		want = slices.Clone(xs)
+	)
+	slices.Sort(want)
+	vs := Quantiles(xs, qs)
+	for i, q := range qs {
+		r := int(q * float64(size-1))
+		if vs[i] != want[r] || xs[r] != want[r] {
+			t.Errorf("quantile %v of %d %s was %d instead of %d", q, size, name, vs[i], want[r])
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package radixsort
+
+import (
+	"math/bits"
+)
+
+// Algorithm selects the radix sort implementation used by a Sorter.
+type Algorithm int
+
+const (
+	// Auto uses LSD radix sort for 32bits types and MSD radix sort for 64bits
+	// types, on the bits that vary in arrays of values in a narrow range, like
+	// the Int32 and Int64 wrappers.
+	Auto Algorithm = iota
+	// MSD always uses most significant digit radix sort, on all digits.
+	MSD
+	// LSD always uses least significant digit radix sort, on all digits.
+	LSD
+	// InPlace always uses in place most significant digit radix sort, which
+	// needs no swap space.
+	InPlace
+)
+
+// A Sorter owns the swap space used by radix sorts, and grows it as needed so
+// that repeated sorts stop allocating. A Sorter must not be used by several
+// goroutines at the same time: keep one per goroutine, or get them from a
+// sync.Pool. The swap space is typed: a Sorter used for several
+// element types reallocates it when the type changes. The zero value is ready
+// to use with the same configuration as the package functions.
+type Sorter struct {
+	// Algorithm selects the radix sort: Auto, MSD, LSD or InPlace, as
+	// described by the Algorithm constants. The zero value is Auto.
+	Algorithm Algorithm
+	// Arrays of InsertionCutoff elements or less are sorted by insertion sort.
+	// Zero or less means 64.
+	InsertionCutoff int
+	// MSD buckets of BucketCutoff elements or less are sorted by insertion sort.
+	// Zero or less means 100.
+	BucketCutoff int
+	// MSD and LSD radix sorts use digits of DigitBits bits: 8, 11 or 16. Zero
+	// selects the width from the length of arrays and the size of elements.
+	// Other values panic. MSD radix sort narrows digits down to 8 bits for
+	// buckets too small to fill wider digits. InPlace always uses 8 bits digits.
+	DigitBits int
+	// Presorted enables a scan of arrays before sorting them: arrays already in
+	// increasing order are left as is, arrays in decreasing order are reversed,
+	// and for arrays in increasing order but for a tail of at most 1/8 of their
+	// elements, only the tail is sorted and merged into the array. InPlace
+	// radix sort does not merge tails, which needs swap space.
+	Presorted bool
+
+	temp   any           // swap space, a slice of the last sorted element type
+	tables digits_tables // count tables of 16bits digits
+}
+
+// Radix sort for int32.
+func (s *Sorter) Int32(xs []int32) { sorter_sort32(s, xs, 1<<7) }
+
+// Radix sort for uint32.
+func (s *Sorter) Uint32(xs []uint32) { sorter_sort32(s, xs, 0) }
+
+// Radix sort for int64.
+func (s *Sorter) Int64(xs []int64) { sorter_sort64(s, xs, 1<<7) }
+
+// Radix sort for uint64.
+func (s *Sorter) Uint64(xs []uint64) { sorter_sort64(s, xs, 0) }
+
+// Radix sort for int, delegating to Int32 or Int64 depending on the size of int.
+func (s *Sorter) Int(xs []int) {
+	if bits.UintSize == 64 {
+		integer_as(xs, s.Int64)
+	} else {
+		integer_as(xs, s.Int32)
+	}
+}
+
+// Radix sort for uint, delegating to Uint32 or Uint64 depending on the size of
+// uint.
+func (s *Sorter) Uint(xs []uint) {
+	if bits.UintSize == 64 {
+		integer_as(xs, s.Uint64)
+	} else {
+		integer_as(xs, s.Uint32)
+	}
+}
+
+// Radix sort for float32, in the same order as Float32.
+func (s *Sorter) Float32(xs []float32) { float32_sort(xs, s.Uint32, nans_first) }
+
+// Radix sort for float64, in the same order as Float64.
+func (s *Sorter) Float64(xs []float64) { float64_sort(xs, s.Uint64, nans_first) }
+
+func sorter_sort32[T word32](s *Sorter, xs []T, offset T) {
+	if len(xs) <= s.insertion_cutoff() {
+		integer_insertion(xs)
+		return
+	}
+	if s.Presorted && presorted_sort(s, xs, offset, sorter_sort32[T]) {
+		return
+	}
+	if s.Algorithm == InPlace {
+		int32_flag(xs, offset, s.bucket_cutoff())
+		return
+	}
+	temp := sorter_temp[T](s, len(xs))
+	if s.Algorithm == Auto && range_sort(xs, temp, uint64(offset)<<24, 32, s.bucket_cutoff(), s.digit_bits(), &s.tables) {
+		return
+	}
+	if s.Algorithm == MSD {
+		int32_msd(xs, temp, offset, 0, s.bucket_cutoff(), s.digit_bits(), &s.tables)
+	} else {
+		int32_lsd(xs, temp, offset, 0, s.digit_bits(), &s.tables)
+	}
+}
+
+func sorter_sort64[T word64](s *Sorter, xs []T, offset T) {
+	if len(xs) <= s.insertion_cutoff() {
+		integer_insertion(xs)
+		return
+	}
+	if s.Presorted && presorted_sort(s, xs, offset, sorter_sort64[T]) {
+		return
+	}
+	if s.Algorithm == InPlace {
+		int64_flag(xs, offset, s.bucket_cutoff())
+		return
+	}
+	temp := sorter_temp[T](s, len(xs))
+	if s.Algorithm == Auto && range_sort(xs, temp, uint64(offset)<<56, 64, s.bucket_cutoff(), s.digit_bits(), &s.tables) {
+		return
+	}
+	if s.Algorithm == LSD {
+		int64_lsd(xs, temp, offset, 0, s.digit_bits(), &s.tables)
+	} else {
+		int64_msd(xs, temp, offset, 0, s.bucket_cutoff(), s.digit_bits(), &s.tables)
+	}
+}
+
+// sorter_temp returns n elements of swap space of type T. The swap space is
+// kept across calls, and reallocated when the element type changes.
+func sorter_temp[T Integer](s *Sorter, n int) []T {
+	if temp, ok := s.temp.([]T); ok && cap(temp) >= n {
+		return temp[:n]
+	}
+	temp := make([]T, n)
+	s.temp = temp
+	return temp
+}
+
+func (s *Sorter) insertion_cutoff() int {
+	if s.InsertionCutoff <= 0 {
+		return 64
+	}
+	return s.InsertionCutoff
+}
+
+func (s *Sorter) bucket_cutoff() int {
+	if s.BucketCutoff <= 0 {
+		return 100
+	}
+	return s.BucketCutoff
+}
+
+func (s *Sorter) digit_bits() uint {
+	switch s.DigitBits {
+	case 0, 8, 11, 16:
+		return uint(s.DigitBits)
+	}
+	panic("radixsort: digit width must be 8, 11 or 16 bits")
+}
@@ -0,0 +1,93 @@
+package radixsort
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSorter(t *testing.T) {
+	var (
+		sizes   = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+		sorters = map[string]*Sorter{
+			"default":           {},
+			"MSD":               {Algorithm: MSD},
+			"LSD":               {Algorithm: LSD},
+			"MSD small cutoffs": {Algorithm: MSD, InsertionCutoff: 1, BucketCutoff: 1},
+			"LSD large cutoff":  {Algorithm: LSD, InsertionCutoff: 500},
+			"in place":          {Algorithm: InPlace},
+			"MSD 11bits digits": {Algorithm: MSD, DigitBits: 11},
+			"LSD 16bits digits": {Algorithm: LSD, DigitBits: 16},
+			"MSD 16bits digits": {Algorithm: MSD, DigitBits: 16},
+		}
+	)
+	for desc, s := range sorters {
+		for _, size := range sizes {
+			i32, u32 := int32_pop(size), uint32_pop(size)
+			i64, u64 := int64_pop(size), uint64_pop(size)
+			i, u := int_pop(size), uint_pop(size)
+			f32, f64 := float32_pop(size), float64_pop(size)
+			s.Int32(i32)
+			s.Uint32(u32)
+			s.Int64(i64)
+			s.Uint64(u64)
+			s.Int(i)
+			s.Uint(u)
+			s.Float32(f32)
+			s.Float64(f64)
+			if !sort.IsSorted(byInt32(i32)) || !sort.IsSorted(byUint32(u32)) ||
+				!sort.IsSorted(byInt64(i64)) || !sort.IsSorted(byUint64(u64)) ||
+				!sort.IntsAreSorted(i) || !sort.IsSorted(byUint(u)) ||
+				!sort.IsSorted(byFloat32(f32)) || !sort.Float64sAreSorted(f64) {
+				t.Errorf("array of size %d was not correctly sorted by %s sorter", size, desc)
+			}
+		}
+	}
+}
+
+func TestSorterAllocs(t *testing.T) {
+	sorters := map[string]Sorter{
+		"default":           {},
+		"MSD 16bits digits": {Algorithm: MSD, DigitBits: 16},
+		"LSD 16bits digits": {Algorithm: LSD, DigitBits: 16},
+	}
+	for desc, s := range sorters {
+		var (
+			s32, s64 = s, s // the swap space is typed
+			i32, u64 = int32_pop(1 << 18), uint64_pop(1 << 18)
+			ys, zs   = make([]int32, len(i32)), make([]uint64, len(u64))
+		)
+		allocs := testing.AllocsPerRun(2, func() {
+			copy(ys, i32)
+			copy(zs, u64)
+			s32.Int32(ys)
+			s64.Uint64(zs)
+		})
+		if allocs != 0 {
+			t.Errorf("%s sorter allocated %v times per repeated sort", desc, allocs)
+		}
+		if !sort.IsSorted(byInt32(ys)) || !sort.IsSorted(byUint64(zs)) {
+			t.Errorf("array of size %d was not correctly sorted by %s sorter", len(ys), desc)
+		}
+	}
+}
+
+func Benchmark_Int64_Sorter_10000(b *testing.B) {
+	s := &Sorter{}
+	b.ReportAllocs()
+	benchmarkInt64(b, s.Int64, 10000)
+}
+func Benchmark_Int64_SorterPool_10000(b *testing.B) {
+	pool := sync.Pool{New: func() any { return &Sorter{} }}
+	b.ReportAllocs()
+	benchmarkInt64(b, func(xs []int64) {
+		s := pool.Get().(*Sorter)
+		s.Int64(xs)
+		pool.Put(s)
+	}, 10000)
+}
+func Benchmark_Int32_Sorter_10000(b *testing.B) {
+	s := &Sorter{}
+	b.ReportAllocs()
+	benchmarkInt32(b, s.Int32, 10000)
+}
@@ -0,0 +1,66 @@
+package radixsort
+
+// Stable sorts of records by an extracted uint64 key. Elements with equal keys
+// keep their input order on every path, including the insertion sort of small
+// arrays and buckets. The key function is called once per element. To sort by
+// a signed key k, return uint64(k) ^ 1<<63.
+//
+// The bare integer sorts, such as Int64MSD, are not documented as stable since
+// equal integers cannot be told apart.
+
+// StableBy sorts xs in increasing order of the uint64 key extracted from every
+// element by key, and keeps equal keys in input order. It uses most
+// significant digit radix sort, which skips the low digits of keys that are
+// told apart by their high digits, like Int64.
+func StableBy[T any](xs []T, key func(T) uint64) { StableByMSD(xs, key) }
+
+// StableByMSD is StableBy with most significant digit radix sort. Elements are
+// paired with their keys and sorted by fixed_most_significant_digit, which
+// scatters every bucket in input order. Buckets of 100 elements or less are
+// sorted by insertion sort, which only moves an element before greater keys.
+func StableByMSD[T any](xs []T, key func(T) uint64) {
+	ps := make([]keyed[T], len(xs))
+	for i, x := range xs {
+		ps[i] = keyed[T]{key(x), x}
+	}
+	if len(ps) <= 64 {
+		keyed_insertion(ps, 0)
+	} else {
+		fixed_msd(ps, &fixed_order[keyed[T]]{7, keyed_digits[T], keyed_insertion[T]})
+	}
+	for i, p := range ps {
+		xs[i] = p.v
+	}
+}
+
+// StableByLSD is StableBy with least significant digit radix sort, as SortBy.
+// Every pass scatters elements in input order, so that the pass of a digit
+// keeps the order of the passes of less significant digits among equal digits.
+func StableByLSD[T any](xs []T, key func(T) uint64) { SortBy(xs, key) }
+
+// keyed is an element of StableByMSD paired with its key.
+type keyed[T any] struct {
+	k uint64
+	v T
+}
+
+// keyed_digits sets ds[i] to the byte of the key of xs[i] at depth, from the
+// most significant byte at depth 0.
+func keyed_digits[T any](xs []keyed[T], ds []uint8, depth int) {
+	shift := 56 - 8*uint(depth)
+	for i := range xs {
+		ds[i] = uint8(xs[i].k >> shift)
+	}
+}
+
+// keyed_insertion sorts xs by key, keeping the input order of equal keys.
+func keyed_insertion[T any](xs []keyed[T], depth int) {
+	for i := 1; i < len(xs); i++ {
+		j, x := i, xs[i]
+		for j > 0 && x.k < xs[j-1].k {
+			xs[j] = xs[j-1]
+			j--
+		}
+		xs[j] = x
+	}
+}
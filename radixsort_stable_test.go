@@ -0,0 +1,65 @@
+package radixsort
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestStable(t *testing.T) {
+	var (
+		sizes   = []int{0, 1, 2, 3, 10, 64, 65, 1e2, 1e3, 1e4, 1e5}
+		sorters = map[string]func([]record){
+			"StableBy":    func(xs []record) { StableBy(xs, record_key) },
+			"StableByMSD": func(xs []record) { StableByMSD(xs, record_key) },
+			"StableByLSD": func(xs []record) { StableByLSD(xs, record_key) },
+		}
+	)
+	for _, size := range sizes {
+		for dist, mask := range stable_masks {
+			xs := record_pop(size, mask)
+			for desc, s := range sorters {
+				stable_check(t, desc+" "+dist, s, xs)
+			}
+		}
+	}
+}
+
+func Benchmark_Record_StableByMSD_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) { StableByMSD(xs, record_key) }, 100000)
+}
+func Benchmark_Record_StableByLSD_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) { StableByLSD(xs, record_key) }, 100000)
+}
+func Benchmark_Record_StandardStableFunc_100000(b *testing.B) {
+	benchmarkRecord(b, func(xs []record) {
+		slices.SortStableFunc(xs, func(a, b record) int { return cmp.Compare(a.key, b.key) })
+	}, 100000)
+}
+
+// stable_masks restrict random keys to duplicate-heavy inputs: all keys equal,
+// a few distinct keys, keys that only differ in their high byte, so that the
+// buckets of the first MSD digit are sorted by insertion sort, and keys that
+// differ in their high and low bytes, so that digits in between are skipped.
+var stable_masks = map[string]uint64{
+	"equal":     0,
+	"few":       0x3,
+	"high":      0xFF00000000000000,
+	"high low":  0xFF000000000000FF,
+	"two bytes": 0xFF00000000000F00,
+	"random":    ^uint64(0),
+}
+
+// stable_check sorts a copy of xs with sort, and compares it with the output
+// of slices.SortStableFunc, which also checks that it is a permutation of xs.
+func stable_check(t *testing.T, name string, sort func([]record), xs []record) {
+	var (
+		ys   = slices.Clone(xs)
+		want = slices.Clone(xs)
+	)
+	slices.SortStableFunc(want, func(a, b record) int { return cmp.Compare(a.key, b.key) })
+	sort(ys)
+	if !slices.Equal(ys, want) {
+		t.Errorf("array of size %d was not stably sorted by %s", len(xs), name)
+	}
+}
@@ -0,0 +1,134 @@
+package radixsort
+
+// Most significant digit radix sort for strings, in the same order as
+// sort.Strings.
+func Strings(xs []string) { bytes_sort(xs) }
+
+// Most significant digit radix sort for byte slices, in the same order as
+// bytes.Compare.
+func Bytes(xs [][]byte) { bytes_sort(xs) }
+
+func bytes_sort[S ~string | ~[]byte](xs []S) {
+	if len(xs) <= 256 {
+		bytes_multikey(xs, 0)
+		return
+	}
+	bytes_most_significant_digit(xs, make([]S, len(xs)), 0)
+}
+
+// bytes_most_significant_digit sorts xs, which all have the same first depth
+// bytes, on the byte at depth. Bucket 0 holds strings of length depth, which
+// are all equal, and bucket b+1 holds strings whose byte at depth is b.
+// Buckets of 256 strings or less are sorted by multikey quicksort.
+func bytes_most_significant_digit[S ~string | ~[]byte](xs, temp []S, depth int) {
+	var cs, is [257]int
+	for {
+		for _, x := range xs {
+			cs[bytes_digit(x, depth)]++
+		}
+		if cs[bytes_digit(xs[0], depth)] < len(xs) {
+			break
+		}
+		// all strings share the byte at depth, no need to move them
+		if len(xs[0]) == depth {
+			return
+		}
+		cs[bytes_digit(xs[0], depth)] = 0
+		depth++
+	}
+
+	a := 0
+	for i := range cs {
+		is[i] = a
+		a += cs[i]
+	}
+	for _, x := range xs {
+		r := bytes_digit(x, depth)
+		temp[is[r]] = x
+		is[r]++
+	}
+	copy(xs, temp)
+
+	lo := cs[0] // strings of length depth are already sorted
+	for i := 1; i < len(cs); i++ {
+		var (
+			c  = cs[i]
+			hi = lo + c
+			zs = xs[lo:hi]
+		)
+		lo = hi
+
+		switch {
+		case c < 2: // already sorted
+		case c <= 256:
+			bytes_multikey(zs, depth+1)
+		default:
+			bytes_most_significant_digit(zs, temp, depth+1)
+		}
+	}
+}
+
+// bytes_digit returns the radix bucket of x at depth: 0 past the end of x, and
+// the byte at depth plus 1 otherwise.
+func bytes_digit[S ~string | ~[]byte](x S, depth int) int {
+	if depth < len(x) {
+		return int(x[depth]) + 1
+	}
+	return 0
+}
+
+// bytes_multikey sorts xs, which all have the same first depth bytes, by
+// multikey quicksort: xs is partitioned in 3 around the byte at depth of a
+// pivot, and the middle partition is sorted on the next byte. Partitions of 16
+// strings or less are sorted by insertion sort.
+func bytes_multikey[S ~string | ~[]byte](xs []S, depth int) {
+	for len(xs) > 16 {
+		var (
+			p      = bytes_digit(xs[len(xs)/2], depth)
+			lt, gt = 0, len(xs)
+		)
+		for i := 0; i < gt; {
+			switch r := bytes_digit(xs[i], depth); {
+			case r < p:
+				xs[lt], xs[i] = xs[i], xs[lt]
+				lt++
+				i++
+			case r > p:
+				gt--
+				xs[gt], xs[i] = xs[i], xs[gt]
+			default:
+				i++
+			}
+		}
+		bytes_multikey(xs[:lt], depth)
+		bytes_multikey(xs[gt:], depth)
+		if p == 0 { // strings of length depth are all equal
+			return
+		}
+		xs = xs[lt:gt]
+		depth++
+	}
+	bytes_insertion(xs, depth)
+}
+
+// bytes_insertion sorts xs, which all have the same first depth bytes.
+func bytes_insertion[S ~string | ~[]byte](xs []S, depth int) {
+	for i := 1; i < len(xs); i++ {
+		j, x := i, xs[i]
+		for j > 0 && bytes_less(x, xs[j-1], depth) {
+			xs[j] = xs[j-1]
+			j--
+		}
+		xs[j] = x
+	}
+}
+
+// bytes_less compares a and b from depth onwards.
+func bytes_less[S ~string | ~[]byte](a, b S, depth int) bool {
+	for i := depth; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,105 @@
+package radixsort
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+)
+
+func TestStringSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5}
+		inputs = map[string]func(int) []string{
+			"random strings": string_pop,
+			"urls":           string_urls,
+		}
+	)
+	for name, pop := range inputs {
+		for _, size := range sizes {
+			xs := pop(size)
+			ys := make([]string, size)
+			copy(ys, xs)
+			Strings(ys)
+			if !sort.StringsAreSorted(ys) {
+				t.Errorf("array of %d %s was not correctly sorted by radix sort", size, name)
+			}
+
+			bs := make([][]byte, size)
+			for i, x := range xs {
+				bs[i] = []byte(x)
+			}
+			Bytes(bs)
+			for i := 1; i < len(bs); i++ {
+				if bytes.Compare(bs[i-1], bs[i]) > 0 {
+					t.Errorf("array of %d %s was not correctly sorted by bytes radix sort", size, name)
+					break
+				}
+			}
+		}
+	}
+}
+
+func Benchmark_String_Radix_100(b *testing.B)    { benchmarkString(b, Strings, string_pop, 100) }
+func Benchmark_String_Radix_1000(b *testing.B)   { benchmarkString(b, Strings, string_pop, 1000) }
+func Benchmark_String_Radix_10000(b *testing.B)  { benchmarkString(b, Strings, string_pop, 10000) }
+func Benchmark_String_Radix_100000(b *testing.B) { benchmarkString(b, Strings, string_pop, 100000) }
+
+func Benchmark_String_StandardSort_100(b *testing.B) {
+	benchmarkString(b, sort.Strings, string_pop, 100)
+}
+func Benchmark_String_StandardSort_1000(b *testing.B) {
+	benchmarkString(b, sort.Strings, string_pop, 1000)
+}
+func Benchmark_String_StandardSort_10000(b *testing.B) {
+	benchmarkString(b, sort.Strings, string_pop, 10000)
+}
+func Benchmark_String_StandardSort_100000(b *testing.B) {
+	benchmarkString(b, sort.Strings, string_pop, 100000)
+}
+
+func Benchmark_URL_Radix_100000(b *testing.B) { benchmarkString(b, Strings, string_urls, 100000) }
+func Benchmark_URL_StandardSort_100000(b *testing.B) {
+	benchmarkString(b, sort.Strings, string_urls, 100000)
+}
+
+func benchmarkString(b *testing.B, sorter func([]string), pop func(int) []string, size int) {
+	ys := make([][]string, b.N)
+	for n := range ys {
+		ys[n] = pop(size)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sorter(ys[n])
+	}
+}
+
+// string_pop returns strings of 0 to 15 letters from a 4 letters alphabet,
+// which gives many shared prefixes, duplicates and prefixes of other strings.
+func string_pop(size int) []string {
+	xs := make([]string, size)
+	for i := range xs {
+		u := g.next()
+		bs := make([]byte, u%16)
+		for j := range bs {
+			u >>= 2
+			bs[j] = 'a' + byte(u%4)
+		}
+		xs[i] = string(bs)
+	}
+	return xs
+}
+
+// string_urls returns strings with a long common prefix and a random suffix.
+func string_urls(size int) []string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789/"
+	xs := make([]string, size)
+	for i := range xs {
+		u := g.next()
+		bs := []byte("https://www.example.com/")
+		for j := 0; j < 4+int(u%24); j++ {
+			bs = append(bs, letters[g.next()%uint64(len(letters))])
+		}
+		xs[i] = string(bs)
+	}
+	return xs
+}
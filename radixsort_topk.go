@@ -0,0 +1,123 @@
+package radixsort
+
+// Partial radix sort for int32. Int32SmallestK moves the k smallest elements of
+// xs to xs[:k] in increasing order, and leaves the other elements in
+// unspecified order. All of xs is sorted if k is greater than its length.
+// Panics if k is negative.
+func Int32SmallestK(xs []int32, k int) { partial_sort(xs, k, 1<<7, 32, 0, Int32) }
+
+// Partial radix sort for uint32. Uint32SmallestK moves the k smallest elements
+// of xs to xs[:k] in increasing order, and leaves the other elements in
+// unspecified order. All of xs is sorted if k is greater than its length.
+// Panics if k is negative.
+func Uint32SmallestK(xs []uint32, k int) { partial_sort(xs, k, 0, 32, 0, Uint32) }
+
+// Partial radix sort for int64. Int64SmallestK moves the k smallest elements of
+// xs to xs[:k] in increasing order, and leaves the other elements in
+// unspecified order. All of xs is sorted if k is greater than its length.
+// Panics if k is negative.
+func Int64SmallestK(xs []int64, k int) { partial_sort(xs, k, 1<<7, 64, 0, Int64) }
+
+// Partial radix sort for uint64. Uint64SmallestK moves the k smallest elements
+// of xs to xs[:k] in increasing order, and leaves the other elements in
+// unspecified order. All of xs is sorted if k is greater than its length.
+// Panics if k is negative.
+func Uint64SmallestK(xs []uint64, k int) { partial_sort(xs, k, 0, 64, 0, Uint64) }
+
+// Partial radix sort for int32 in decreasing order. Int32LargestK moves the k
+// largest elements of xs to xs[:k] in decreasing order, and leaves the other
+// elements in unspecified order. All of xs is sorted if k is greater than its
+// length. Panics if k is negative.
+func Int32LargestK(xs []int32, k int) { partial_sort(xs, k, 1<<7, 32, 0xFF, Int32Desc) }
+
+// Partial radix sort for uint32 in decreasing order. Uint32LargestK moves the k
+// largest elements of xs to xs[:k] in decreasing order, and leaves the other
+// elements in unspecified order. All of xs is sorted if k is greater than its
+// length. Panics if k is negative.
+func Uint32LargestK(xs []uint32, k int) { partial_sort(xs, k, 0, 32, 0xFF, Uint32Desc) }
+
+// Partial radix sort for int64 in decreasing order. Int64LargestK moves the k
+// largest elements of xs to xs[:k] in decreasing order, and leaves the other
+// elements in unspecified order. All of xs is sorted if k is greater than its
+// length. Panics if k is negative.
+func Int64LargestK(xs []int64, k int) { partial_sort(xs, k, 1<<7, 64, 0xFF, Int64Desc) }
+
+// Partial radix sort for uint64 in decreasing order. Uint64LargestK moves the k
+// largest elements of xs to xs[:k] in decreasing order, and leaves the other
+// elements in unspecified order. All of xs is sorted if k is greater than its
+// length. Panics if k is negative.
+func Uint64LargestK(xs []uint64, k int) { partial_sort(xs, k, 0, 64, 0xFF, Uint64Desc) }
+
+// partial_sort moves the k first elements of xs in the order of sort to xs[:k],
+// and sorts them with sort. Digits are taken like in
+// int64_most_significant_digit, with offset on the most significant digit of
+// width bits values and buckets laid out in decreasing order if mask is 0xFF.
+//
+// Every digit position from the most significant one is counted like in
+// int64_most_significant_digit, to find the bucket holding the k-th element.
+// Elements of buckets up to that bucket are moved to the front of xs in place,
+// those of previous buckets are sorted by sort, and the others are discarded.
+// The search goes on in the boundary bucket with the next digit, so that only
+// the k first elements and the boundary buckets are ever moved.
+func partial_sort[T word](xs []T, k int, offset T, width uint, mask int, sort func([]T)) {
+	if k < 0 {
+		panic("radixsort: k is negative")
+	}
+	if counter_wide(len(xs)) {
+		partial_digits[T, uint64](xs, k, offset, width, mask, sort)
+		return
+	}
+	partial_digits[T, uint32](xs, k, offset, width, mask, sort)
+}
+
+// partial_digits is partial_sort with counters of type C.
+func partial_digits[T word, C counter](xs []T, k int, offset T, width uint, mask int, sort func([]T)) {
+	for shift := width - 8; ; shift -= 8 {
+		switch {
+		case k == 0:
+			return
+		case k >= len(xs) || len(xs) <= 64:
+			sort(xs)
+			return
+		}
+		lo, hi := partial_partition[T, C](xs, k-1, offset, shift, mask)
+		sort(xs[:lo])
+		if shift == 0 { // elements of the boundary bucket are equal
+			return
+		}
+		xs, k, offset = xs[lo:hi], k-lo, 0
+	}
+}
+
+// partial_partition counts the digits at shift of xs with word_histogram to
+// find the bucket holding the element of rank n, and partitions xs in place
+// into the elements of previous buckets, of that bucket, which is returned as
+// xs[lo:hi], and of next buckets.
+func partial_partition[T word, C counter](xs []T, n int, offset T, shift uint, mask int) (lo, hi int) {
+	var cs, is [256]C
+	word_histogram(xs, &cs, &is, offset, shift, mask)
+	b := 0 // position of the bucket holding the element of rank n
+	for int(is[b^mask]+cs[b^mask]) <= n {
+		b++
+	}
+	lo, hi = int(is[b^mask]), int(is[b^mask]+cs[b^mask])
+
+	if b < 0xFF { // no element is in a bucket after the last one
+		partial_front(xs, offset, shift, mask, b+1)
+	}
+	partial_front(xs[:hi], offset, shift, mask, b)
+	return lo, hi
+}
+
+// partial_front moves the elements of xs whose bucket at shift is before the
+// bucket at position b to the front of xs, swapping them with the elements
+// they replace.
+func partial_front[T word](xs []T, offset T, shift uint, mask, b int) {
+	n := 0
+	for i, x := range xs {
+		if int((offset+(x>>shift))&0xFF)^mask < b {
+			xs[n], xs[i] = x, xs[n]
+			n++
+		}
+	}
+}
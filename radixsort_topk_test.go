@@ -0,0 +1,69 @@
+package radixsort
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	sizes := []int{0, 1, 10, 1e2, 1e3, 1e4}
+	for _, size := range sizes {
+		for _, k := range []int{0, 1, 10, 100, size / 2, size - 1, size, size + 5} {
+			if k < 0 {
+				continue
+			}
+			topk_check(t, "Int32SmallestK", Int32SmallestK, cmp.Compare, size, k)
+			topk_check(t, "Uint32SmallestK", Uint32SmallestK, cmp.Compare, size, k)
+			topk_check(t, "Int64SmallestK", Int64SmallestK, cmp.Compare, size, k)
+			topk_check(t, "Uint64SmallestK", Uint64SmallestK, cmp.Compare, size, k)
+			topk_check(t, "Int32LargestK", Int32LargestK, topk_reverse, size, k)
+			topk_check(t, "Uint32LargestK", Uint32LargestK, topk_reverse, size, k)
+			topk_check(t, "Int64LargestK", Int64LargestK, topk_reverse, size, k)
+			topk_check(t, "Uint64LargestK", Uint64LargestK, topk_reverse, size, k)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Uint64SmallestK did not panic on a negative k")
+		}
+	}()
+	Uint64SmallestK(make([]uint64, 10), -1)
+}
+
+func Benchmark_Uint64_SmallestK100_1000000(b *testing.B) {
+	benchmarkUint64(b, func(xs []uint64) { Uint64SmallestK(xs, 100) }, 1000000)
+}
+func Benchmark_Uint64_SmallestK10000_1000000(b *testing.B) {
+	benchmarkUint64(b, func(xs []uint64) { Uint64SmallestK(xs, 10000) }, 1000000)
+}
+func Benchmark_Uint64_LargestK100_1000000(b *testing.B) {
+	benchmarkUint64(b, func(xs []uint64) { Uint64LargestK(xs, 100) }, 1000000)
+}
+
+// topk_check runs topk on random arrays, arrays with many duplicates, and
+// arrays of values in a narrow range. It checks that xs[:k] is the k first
+// elements of xs sorted by compare, and that xs is still a permutation.
+func topk_check[T word](t *testing.T, name string, topk func([]T, int), compare func(T, T) int, size, k int) {
+	for _, mask := range []T{^T(0), 0xFF, 0xFFFF00} {
+		xs := generic_pop[T](size)
+		for i := range xs {
+			xs[i] &= mask
+		}
+		want := slices.Clone(xs)
+		slices.SortFunc(want, compare)
+
+		topk(xs, k)
+		n := min(k, size)
+		if !slices.Equal(xs[:n], want[:n]) {
+			t.Errorf("%s did not select the %d first of %d elements with mask %x", name, k, size, mask)
+		}
+		slices.SortFunc(xs, compare)
+		if !slices.Equal(xs, want) {
+			t.Errorf("%s lost elements of an array of size %d with mask %x", name, size, mask)
+		}
+	}
+}
+
+func topk_reverse[T word](x, y T) int { return cmp.Compare(y, x) }
@@ -9,9 +9,16 @@ func TestUint32Sorting(t *testing.T) {
 	var (
 		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
 		sorter = map[string]func([]uint32){
-			"uint32 radix sort MSD": Uint32MSD,
-			"uint32 radix sort LSD": Uint32LSD,
-			"uint32 standard sort":  uint32_stdSort,
+			"uint32 radix sort MSD":      Uint32MSD,
+			"uint32 radix sort LSD":      Uint32LSD,
+			"uint32 radix sort in place": Uint32InPlace,
+			"uint32 standard sort":       uint32_stdSort,
+			"uint32 radix sort MSD with buffer": func(xs []uint32) {
+				Uint32MSDWithBuffer(xs, make([]uint32, len(xs)+7))
+			},
+			"uint32 radix sort LSD with buffer": func(xs []uint32) {
+				Uint32LSDWithBuffer(xs, make([]uint32, len(xs)+7))
+			},
 		}
 	)
 	for _, size := range sizes {
@@ -27,6 +34,31 @@ func TestUint32Sorting(t *testing.T) {
 	}
 }
 
+func TestUint32DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]uint32){
+			"uint32 radix sort desc":     Uint32Desc,
+			"uint32 radix sort MSD desc": Uint32MSDDesc,
+			"uint32 radix sort LSD desc": Uint32LSDDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := uint32_pop(size)
+		for n := 0; n < size; n += 2 { // add duplicates
+			xs[n] &= 0xFFFF
+		}
+		for desc, s := range sorter {
+			ys := make([]uint32, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.IsSorted(sort.Reverse(byUint32(ys))) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
 func Benchmark_Uint32_RadixMSD_100(b *testing.B)    { benchmarkUint32(b, Uint32MSD, 100) }
 func Benchmark_Uint32_RadixMSD_1000(b *testing.B)   { benchmarkUint32(b, Uint32MSD, 1000) }
 func Benchmark_Uint32_RadixMSD_10000(b *testing.B)  { benchmarkUint32(b, Uint32MSD, 10000) }
@@ -37,12 +69,29 @@ func Benchmark_Uint32_RadixLSD_1000(b *testing.B)   { benchmarkUint32(b, Uint32L
 func Benchmark_Uint32_RadixLSD_10000(b *testing.B)  { benchmarkUint32(b, Uint32LSD, 10000) }
 func Benchmark_Uint32_RadixLSD_100000(b *testing.B) { benchmarkUint32(b, Uint32LSD, 100000) }
 
+func Benchmark_Uint32_RadixInPlace_100(b *testing.B)    { benchmarkUint32(b, Uint32InPlace, 100) }
+func Benchmark_Uint32_RadixInPlace_1000(b *testing.B)   { benchmarkUint32(b, Uint32InPlace, 1000) }
+func Benchmark_Uint32_RadixInPlace_10000(b *testing.B)  { benchmarkUint32(b, Uint32InPlace, 10000) }
+func Benchmark_Uint32_RadixInPlace_100000(b *testing.B) { benchmarkUint32(b, Uint32InPlace, 100000) }
+
+func Benchmark_Uint32_RadixMSDWithBuffer_100000(b *testing.B) {
+	buf := make([]uint32, 100000)
+	benchmarkUint32(b, func(xs []uint32) { Uint32MSDWithBuffer(xs, buf) }, 100000)
+}
+func Benchmark_Uint32_RadixLSDWithBuffer_100000(b *testing.B) {
+	buf := make([]uint32, 100000)
+	benchmarkUint32(b, func(xs []uint32) { Uint32LSDWithBuffer(xs, buf) }, 100000)
+}
+
+func Benchmark_Uint32_RadixMSDDesc_100000(b *testing.B) { benchmarkUint32(b, Uint32MSDDesc, 100000) }
+func Benchmark_Uint32_RadixLSDDesc_100000(b *testing.B) { benchmarkUint32(b, Uint32LSDDesc, 100000) }
+
 func Benchmark_Uint32_StandardSort_100(b *testing.B)    { benchmarkUint32(b, uint32_stdSort, 100) }
 func Benchmark_Uint32_StandardSort_1000(b *testing.B)   { benchmarkUint32(b, uint32_stdSort, 1000) }
 func Benchmark_Uint32_StandardSort_10000(b *testing.B)  { benchmarkUint32(b, uint32_stdSort, 10000) }
 func Benchmark_Uint32_StandardSort_100000(b *testing.B) { benchmarkUint32(b, uint32_stdSort, 100000) }
 
-func Benchmark_Uint32_Insertion_100(b *testing.B) { benchmarkUint32(b, uint32_insertion, 100) }
+func Benchmark_Uint32_Insertion_100(b *testing.B) { benchmarkUint32(b, integer_insertion[uint32], 100) }
 
 func benchmarkUint32(b *testing.B, sorter func([]uint32), size int) {
 	ys := make([][]uint32, b.N)
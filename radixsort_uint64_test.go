@@ -9,9 +9,16 @@ func TestUint64Sorting(t *testing.T) {
 	var (
 		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
 		sorter = map[string]func([]uint64){
-			"uint64 radix sort MSD": Uint64MSD,
-			"uint64 radix sort LSD": Uint64LSD,
-			"uint64 standard sort":  uint64_stdSort,
+			"uint64 radix sort MSD":      Uint64MSD,
+			"uint64 radix sort LSD":      Uint64LSD,
+			"uint64 radix sort in place": Uint64InPlace,
+			"uint64 standard sort":       uint64_stdSort,
+			"uint64 radix sort MSD with buffer": func(xs []uint64) {
+				Uint64MSDWithBuffer(xs, make([]uint64, len(xs)+7))
+			},
+			"uint64 radix sort LSD with buffer": func(xs []uint64) {
+				Uint64LSDWithBuffer(xs, make([]uint64, len(xs)+7))
+			},
 		}
 	)
 	for _, size := range sizes {
@@ -27,6 +34,31 @@ func TestUint64Sorting(t *testing.T) {
 	}
 }
 
+func TestUint64DescSorting(t *testing.T) {
+	var (
+		sizes  = []int{0, 1, 2, 3, 10, 1e2, 1e3, 1e4, 1e5, 1e6}
+		sorter = map[string]func([]uint64){
+			"uint64 radix sort desc":     Uint64Desc,
+			"uint64 radix sort MSD desc": Uint64MSDDesc,
+			"uint64 radix sort LSD desc": Uint64LSDDesc,
+		}
+	)
+	for _, size := range sizes {
+		xs := uint64_pop(size)
+		for n := 0; n < size; n += 2 { // add duplicates
+			xs[n] &= 0xFFFF
+		}
+		for desc, s := range sorter {
+			ys := make([]uint64, size)
+			copy(ys, xs)
+			s(ys)
+			if !sort.IsSorted(sort.Reverse(byUint64(ys))) {
+				t.Errorf("array of size %d was not correctly sorted by %s", size, desc)
+			}
+		}
+	}
+}
+
 func Benchmark_Uint64_RadixMSD_100(b *testing.B)    { benchmarkUint64(b, Uint64MSD, 100) }
 func Benchmark_Uint64_RadixMSD_1000(b *testing.B)   { benchmarkUint64(b, Uint64MSD, 1000) }
 func Benchmark_Uint64_RadixMSD_10000(b *testing.B)  { benchmarkUint64(b, Uint64MSD, 10000) }
@@ -37,12 +69,29 @@ func Benchmark_Uint64_RadixLSD_1000(b *testing.B)   { benchmarkUint64(b, Uint64L
 func Benchmark_Uint64_RadixLSD_10000(b *testing.B)  { benchmarkUint64(b, Uint64LSD, 10000) }
 func Benchmark_Uint64_RadixLSD_100000(b *testing.B) { benchmarkUint64(b, Uint64LSD, 100000) }
 
+func Benchmark_Uint64_RadixInPlace_100(b *testing.B)    { benchmarkUint64(b, Uint64InPlace, 100) }
+func Benchmark_Uint64_RadixInPlace_1000(b *testing.B)   { benchmarkUint64(b, Uint64InPlace, 1000) }
+func Benchmark_Uint64_RadixInPlace_10000(b *testing.B)  { benchmarkUint64(b, Uint64InPlace, 10000) }
+func Benchmark_Uint64_RadixInPlace_100000(b *testing.B) { benchmarkUint64(b, Uint64InPlace, 100000) }
+
+func Benchmark_Uint64_RadixMSDWithBuffer_100000(b *testing.B) {
+	buf := make([]uint64, 100000)
+	benchmarkUint64(b, func(xs []uint64) { Uint64MSDWithBuffer(xs, buf) }, 100000)
+}
+func Benchmark_Uint64_RadixLSDWithBuffer_100000(b *testing.B) {
+	buf := make([]uint64, 100000)
+	benchmarkUint64(b, func(xs []uint64) { Uint64LSDWithBuffer(xs, buf) }, 100000)
+}
+
+func Benchmark_Uint64_RadixMSDDesc_100000(b *testing.B) { benchmarkUint64(b, Uint64MSDDesc, 100000) }
+func Benchmark_Uint64_RadixLSDDesc_100000(b *testing.B) { benchmarkUint64(b, Uint64LSDDesc, 100000) }
+
 func Benchmark_Uint64_StandardSort_100(b *testing.B)    { benchmarkUint64(b, uint64_stdSort, 100) }
 func Benchmark_Uint64_StandardSort_1000(b *testing.B)   { benchmarkUint64(b, uint64_stdSort, 1000) }
 func Benchmark_Uint64_StandardSort_10000(b *testing.B)  { benchmarkUint64(b, uint64_stdSort, 10000) }
 func Benchmark_Uint64_StandardSort_100000(b *testing.B) { benchmarkUint64(b, uint64_stdSort, 100000) }
 
-func Benchmark_Uint64_Insertion_100(b *testing.B) { benchmarkUint64(b, uint64_insertion, 100) }
+func Benchmark_Uint64_Insertion_100(b *testing.B) { benchmarkUint64(b, integer_insertion[uint64], 100) }
 
 func benchmarkUint64(b *testing.B, sorter func([]uint64), size int) {
 	ys := make([][]uint64, b.N)
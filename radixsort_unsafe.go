@@ -0,0 +1,28 @@
+//go:build !purego
+
+package radixsort
+
+import (
+	"unsafe"
+)
+
+// integer_size returns the size of T in bytes.
+func integer_size[T Integer]() uintptr {
+	return unsafe.Sizeof(T(0))
+}
+
+// integer_as sorts xs with sorter by casting the slice pointer. U must have the
+// same size as T.
+func integer_as[T, U Integer](xs []T, sorter func([]U)) {
+	sorter(*(*[]U)(unsafe.Pointer(&xs)))
+}
+
+// float32_as sorts the bits of xs with sorter by casting the slice pointer.
+func float32_as(xs []float32, sorter func([]uint32)) {
+	sorter(*(*[]uint32)(unsafe.Pointer(&xs)))
+}
+
+// float64_as sorts the bits of xs with sorter by casting the slice pointer.
+func float64_as(xs []float64, sorter func([]uint64)) {
+	sorter(*(*[]uint64)(unsafe.Pointer(&xs)))
+}